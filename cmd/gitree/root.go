@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/andreygrechin/gitree/internal/cli"
+	"github.com/andreygrechin/gitree/internal/config"
 	"github.com/andreygrechin/gitree/internal/gitstatus"
 	"github.com/andreygrechin/gitree/internal/models"
 	"github.com/andreygrechin/gitree/internal/reposcan"
@@ -16,6 +20,7 @@ import (
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 const (
@@ -26,6 +31,21 @@ const (
 	defaultContextTimeout = 5 * time.Minute
 )
 
+// Output formats accepted by the --format flag.
+const (
+	formatTree     = "tree"
+	formatJSON     = "json"
+	formatNDJSON   = "ndjson"
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+	formatCSV      = "csv"
+	formatTable    = "table"
+
+	// formatPorcelain and formatList are selected with --porcelain and --list rather than --format.
+	formatPorcelain = "porcelain"
+	formatList      = "list"
+)
+
 //nolint:gochecknoglobals // CLI flags and root command
 var (
 	versionFlag       bool
@@ -33,7 +53,28 @@ var (
 	allFlag           bool
 	debugFlag         bool
 	noFetchFlag       bool
+	fetchAllFlag      bool
+	fetchRemoteFlag   []string
+	pruneFlag         bool
+	fetchTagsFlag     string
+	fetchCurrentFlag  bool
+	fetchMaxAgeFlag   time.Duration
 	maxConcurrentFlag int
+	maxPerHostFlag    int
+	hostFailuresFlag  int
+	formatFlag        string
+	porcelainFlag     bool
+	listFlag          bool
+	compactFlag       bool
+	sortFlag          string
+	groupByFlag       string
+	themeFlag         string
+	configFlag        string
+	trunkFlag         []string
+	branchesFlag      bool
+	showAgeFlag       bool
+	templateFlag      string
+	templateFileFlag  string
 
 	// Root command.
 	rootCmd = &cobra.Command{
@@ -43,11 +84,42 @@ var (
 subdirectories for Git repositories, displays them in a tree structure with status information.
 
 By default, gitree fetches from origin remote before calculating ahead/behind
-counts. Use --no-fetch to skip fetching and use local refs only.
+counts. Use --no-fetch to skip fetching and use local refs only. Use --fetch-all to
+fetch every remote, or --fetch-remote to fetch named remotes such as origin,upstream
+(repositories without them are skipped). Use --prune to remove remote-tracking
+branches deleted on the remote, --fetch-tags to fetch all tags or none, and
+--fetch-current-branch to fetch only the branch the checked-out branch tracks.
+Use --fetch-max-age 15m to skip repositories fetched in the last 15 minutes.
+Fetches from one host are limited by --max-concurrent-per-host, and a host that
+fails to connect --host-failure-limit times in a row is skipped as unreachable.
 
 By default, only repositories needing attention are shown (uncommitted changes,
-non-main/master branches, ahead/behind remote, stashes, or no remote tracking).
-Use --all to show all repositories including clean ones.`,
+branches other than trunk, ahead/behind remote, stashes, or no remote tracking).
+Use --all to show all repositories including clean ones. Trunk branches are main,
+master and each repository's default branch (from origin/HEAD); use --trunk or
+trunk_branches in the config file to use other names or globs such as release/*.
+
+Use --branches to list every local branch under its repository, with its upstream,
+the number of commits that are on no remote-tracking branch and the age of its last
+commit. A repository with unpushed commits on any branch needs attention.
+
+Use --format json to emit the full scan result as a versioned JSON document, or
+--format ndjson to stream one JSON object per repository as soon as its own fetch
+and status are done. Use --format html to write a self-contained HTML report, or
+--format markdown / csv for a flat table with one row per repository. Use --format
+table to show the tree with branch, ahead/behind, stash, changes, commit age and
+remote host in aligned columns sized to the terminal width. Use --show-age to add
+the age of each repository's last commit to the tree, --compact to merge
+single-child directory chains, or --list to print only repository paths. Use --sort
+to order entries by recent commits, recent worktree changes, ahead/behind counts or
+attention severity, and --group-by to group repositories by branch, remote host or
+status category.
+
+Use "gitree stashes" to list every stash in the scanned tree. To scan a directory
+named "stashes", pass it as a path: gitree ./stashes.
+
+Use --theme ascii, nerd or words to change the status symbols and tree connectors;
+custom themes can be defined in $XDG_CONFIG_HOME/gitree/config.yaml.`,
 		Args:          cobra.MaximumNArgs(1),
 		SilenceErrors: true,
 		SilenceUsage:  true,
@@ -76,8 +148,58 @@ func init() { //nolint:gochecknoinits // Cobra CLI initialization
 	rootCmd.Flags().BoolVar(&debugFlag, "debug", false, "Enable debug output")
 	rootCmd.Flags().BoolVar(&noFetchFlag, "no-fetch", false,
 		"Skip fetching from remote (use local refs only)")
+	rootCmd.Flags().BoolVar(&fetchAllFlag, "fetch-all", false, "Fetch every configured remote instead of only origin")
+	rootCmd.Flags().StringSliceVar(&fetchRemoteFlag, "fetch-remote", nil,
+		"Remotes to fetch instead of only origin, e.g. origin,upstream (repositories without them are skipped)")
+	rootCmd.MarkFlagsMutuallyExclusive("fetch-all", "fetch-remote")
+	rootCmd.Flags().BoolVar(&pruneFlag, "prune", false, "Remove remote-tracking branches that no longer exist on the remote")
+	rootCmd.Flags().StringVar(&fetchTagsFlag, "fetch-tags", string(gitstatus.FetchTagsFollow),
+		"Tags to fetch: follow (tags pointing into fetched history), all or none")
+	rootCmd.Flags().BoolVar(&fetchCurrentFlag, "fetch-current-branch", false,
+		"Fetch only the branch the checked-out branch tracks instead of every branch (faster)")
+	rootCmd.Flags().DurationVar(&fetchMaxAgeFlag, "fetch-max-age", 0,
+		"Skip fetching repositories fetched within this duration, e.g. 15m (default 0 always fetches)")
 	rootCmd.Flags().IntVarP(&maxConcurrentFlag, "max-concurrent", "c", defaultMaxConcurrent,
 		"Maximum concurrent git operations")
+	rootCmd.Flags().IntVar(&maxPerHostFlag, "max-concurrent-per-host", gitstatus.DefaultOptions().MaxConcurrencyPerHost,
+		"Maximum concurrent fetches from one remote host (0 for no limit)")
+	rootCmd.Flags().IntVar(&hostFailuresFlag, "host-failure-limit", gitstatus.DefaultOptions().HostFailureLimit,
+		"Skip the remaining fetches from a host after this many consecutive connection failures (0 to never skip)")
+	rootCmd.Flags().StringVar(&formatFlag, "format", formatTree, "Output format: tree, table, json, ndjson, html, markdown or csv")
+	rootCmd.Flags().StringVar(&templateFlag, "template", "",
+		"Go text/template used to render each tree line (tree format only)")
+	rootCmd.Flags().StringVar(&templateFileFlag, "template-file", "",
+		"File containing a Go text/template used to render each tree line (tree format only)")
+	rootCmd.MarkFlagsMutuallyExclusive("template", "template-file")
+	rootCmd.Flags().BoolVar(&porcelainFlag, "porcelain", false,
+		"Print one stable, space-separated line per repository for scripts (format never changes between releases)")
+	rootCmd.MarkFlagsMutuallyExclusive("porcelain", "format")
+	rootCmd.MarkFlagsMutuallyExclusive("porcelain", "template")
+	rootCmd.MarkFlagsMutuallyExclusive("porcelain", "template-file")
+	rootCmd.Flags().BoolVar(&compactFlag, "compact", false,
+		"Merge chains of single-child directories into one path segment (e.g. src/github.com/acme/api)")
+	rootCmd.Flags().StringVar(&sortFlag, "sort", string(models.SortByName),
+		"Sort order of sibling entries: name, commit, modified, ahead, behind or severity")
+	rootCmd.Flags().StringVar(&groupByFlag, "group-by", "",
+		"Group repositories by branch, remote or status instead of the directory hierarchy")
+	rootCmd.MarkFlagsMutuallyExclusive("group-by", "compact")
+	rootCmd.Flags().StringVar(&themeFlag, "theme", "",
+		"Symbol and color theme: default, ascii, nerd, words, or a theme defined in the config file")
+	rootCmd.Flags().StringVar(&configFlag, "config", "",
+		"Path to the config file (default $XDG_CONFIG_HOME/gitree/config.yaml)")
+	rootCmd.Flags().StringSliceVar(&trunkFlag, "trunk", nil,
+		"Branch names or globs treated as trunk, e.g. develop,release/* (default main,master)")
+	rootCmd.Flags().BoolVar(&branchesFlag, "branches", false,
+		"List every local branch under its repository with its upstream, unpushed commits and last commit age")
+	rootCmd.Flags().BoolVar(&showAgeFlag, "show-age", false,
+		"Show the age of each repository's last commit (e.g. 3d ago) in the tree")
+	rootCmd.Flags().BoolVar(&listFlag, "list", false,
+		"Print only the relative path of each repository, one per line (for piping into xargs)")
+	for _, flag := range []string{"format", "porcelain", "template", "template-file", "compact"} {
+		rootCmd.MarkFlagsMutuallyExclusive("list", flag)
+	}
+
+	rootCmd.AddCommand(stashesCmd)
 
 	// Set PersistentPreRun to handle global flags (color suppression)
 	rootCmd.PersistentPreRun = handleGlobalFlags
@@ -107,6 +229,53 @@ func handleVersionFlag(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("%w: flag --max-concurrent must be at least 1, got %d", errInvalidFlags, maxConcurrentFlag)
 	}
 
+	switch formatFlag {
+	case formatTree, formatTable, formatJSON, formatNDJSON, formatHTML, formatMarkdown, formatCSV:
+	default:
+		return fmt.Errorf("%w: flag --format must be one of tree, table, json, ndjson, html, markdown, csv, got %q",
+			errInvalidFlags, formatFlag)
+	}
+
+	if !slices.Contains(models.SortKeys(), models.SortKey(sortFlag)) {
+		return fmt.Errorf("%w: flag --sort must be one of name, commit, modified, ahead, behind, severity, got %q",
+			errInvalidFlags, sortFlag)
+	}
+
+	if maxPerHostFlag < 0 {
+		return fmt.Errorf("%w: flag --max-concurrent-per-host must not be negative, got %d", errInvalidFlags, maxPerHostFlag)
+	}
+
+	if hostFailuresFlag < 0 {
+		return fmt.Errorf("%w: flag --host-failure-limit must not be negative, got %d", errInvalidFlags, hostFailuresFlag)
+	}
+
+	if fetchMaxAgeFlag < 0 {
+		return fmt.Errorf("%w: flag --fetch-max-age must not be negative, got %v", errInvalidFlags, fetchMaxAgeFlag)
+	}
+
+	if !slices.Contains(gitstatus.FetchTagsPolicies(), gitstatus.FetchTagsPolicy(fetchTagsFlag)) {
+		return fmt.Errorf("%w: flag --fetch-tags must be one of follow, all, none, got %q", errInvalidFlags, fetchTagsFlag)
+	}
+
+	if groupByFlag != "" && !slices.Contains(tree.GroupKeys(), tree.GroupKey(groupByFlag)) {
+		return fmt.Errorf("%w: flag --group-by must be one of branch, remote, status, got %q", errInvalidFlags, groupByFlag)
+	}
+
+	// Line templates only render tree lines, so other formats would silently ignore them
+	if (templateFlag != "" || templateFileFlag != "") && formatFlag != formatTree {
+		return fmt.Errorf("%w: flags --template and --template-file require --format tree, got %q", errInvalidFlags, formatFlag)
+	}
+
+	// --porcelain is a dedicated output mode; it is mutually exclusive with --format
+	if porcelainFlag {
+		formatFlag = formatPorcelain
+	}
+
+	// --list is a dedicated output mode as well
+	if listFlag {
+		formatFlag = formatList
+	}
+
 	return nil
 }
 
@@ -121,15 +290,16 @@ func formatVersion(ver, cmt, btime string) string {
 	return fmt.Sprintf("gitree version %s\n  commit: %s\n  built:  %s", ver, cmt, btime)
 }
 
-func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Main command logic
-	// Determine target directory
+// resolveTargetDir returns the absolute path of the directory given as the first argument,
+// or of the current directory, after checking that it exists and is a directory.
+func resolveTargetDir(args []string) (string, error) {
 	var targetDir string
 	if len(args) > 0 {
 		targetDir = args[0]
 	} else {
 		cwd, err := os.Getwd()
 		if err != nil {
-			return fmt.Errorf("unable to get current directory: %w", err)
+			return "", fmt.Errorf("unable to get current directory: %w", err)
 		}
 		targetDir = cwd
 	}
@@ -138,28 +308,49 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 	info, err := os.Stat(targetDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("%w: directory does not exist: %s", errInvalidArgs, targetDir)
+			return "", fmt.Errorf("%w: directory does not exist: %s", errInvalidArgs, targetDir)
 		}
 
-		return fmt.Errorf("%w: cannot access directory: %s: %w", errInvalidArgs, targetDir, err)
+		return "", fmt.Errorf("%w: cannot access directory: %s: %w", errInvalidArgs, targetDir, err)
 	}
 
 	if !info.IsDir() {
-		return fmt.Errorf("%w: not a directory: %s", errInvalidArgs, targetDir)
+		return "", fmt.Errorf("%w: not a directory: %s", errInvalidArgs, targetDir)
 	}
 
 	// Convert to absolute path for consistent handling
 	targetDir, err = filepath.Abs(targetDir)
 	if err != nil {
-		return fmt.Errorf("%w: cannot resolve absolute path: %w", errInvalidArgs, err)
+		return "", fmt.Errorf("%w: cannot resolve absolute path: %w", errInvalidArgs, err)
+	}
+
+	return targetDir, nil
+}
+
+func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Main command logic
+	// Determine target directory
+	targetDir, err := resolveTargetDir(args)
+	if err != nil {
+		return err
+	}
+
+	// Apply the display theme and trunk branches before any output is produced
+	if err := applyConfig(); err != nil {
+		return err
+	}
+
+	// Prepare tree formatting before scanning so template errors are reported immediately
+	formatOpts, err := buildFormatOptions()
+	if err != nil {
+		return err
 	}
 
 	// Initialize spinner
 	s := spinner.New(spinner.CharSets[spinnerCharSetIndex], spinnerDelay)
 	s.Suffix = " Scanning repositories..."
 	s.Writer = os.Stderr
-	// Only start spinner if debug is disabled
-	if !debugFlag {
+	// Only start spinner if debug is disabled and results are not streamed as they arrive
+	if !debugFlag && formatFlag != formatNDJSON {
 		s.Start()
 	}
 
@@ -191,6 +382,12 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 		if !debugFlag {
 			s.Stop()
 		}
+		if formatFlag == formatNDJSON {
+			return tree.NewNDJSONWriter(os.Stdout, targetDir).WriteSummary(scanResult, &models.BatchResult{})
+		}
+		if !isTreeFormat() {
+			return writeOutput(tree.Build(targetDir, nil, formatOpts), nil, scanResult, &models.BatchResult{}, formatOpts)
+		}
 		_, _ = fmt.Fprintln(os.Stdout, "No Git repositories found in this directory.")
 		// Still print summary even when no repos found
 		printSummary(scanResult, &models.BatchResult{})
@@ -213,12 +410,44 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 
 	// Extract Git status concurrently (with fetch if enabled)
 	statusOpts := &gitstatus.ExtractOptions{
-		Timeout:        defaultTimeout,
-		MaxConcurrency: maxConcurrentFlag,
-		Debug:          debugFlag,
-		Fetch:          !noFetchFlag,
+		Timeout:               defaultTimeout,
+		MaxConcurrency:        maxConcurrentFlag,
+		MaxConcurrencyPerHost: maxPerHostFlag,
+		HostFailureLimit:      hostFailuresFlag,
+		Debug:                 debugFlag,
+		Fetch:                 !noFetchFlag,
+		FetchAllRemotes:       fetchAllFlag,
+		FetchRemotes:          fetchRemoteFlag,
+		Prune:                 pruneFlag,
+		FetchTags:             gitstatus.FetchTagsPolicy(fetchTagsFlag),
+		FetchCurrentBranch:    fetchCurrentFlag,
+		FetchMaxAge:           fetchMaxAgeFlag,
+		Branches:              branchesFlag,
 	}
+
+	// Stream every repository, clean or not, as soon as its status is extracted
+	var ndjson *tree.NDJSONWriter
+	var streamErr error
+	if formatFlag == formatNDJSON {
+		ndjson = tree.NewNDJSONWriter(os.Stdout, targetDir)
+		statusOpts.OnResult = func(path string, status *models.GitStatus) {
+			repo, exists := repoMap[path]
+			if !exists || streamErr != nil {
+				return
+			}
+			repo.GitStatus = status
+			streamErr = ndjson.WriteRepository(repo)
+		}
+	}
+
 	batchResult := gitstatus.ExtractBatch(ctx, repoMap, statusOpts)
+	if ndjson != nil {
+		if streamErr != nil {
+			return streamErr
+		}
+
+		return ndjson.WriteSummary(scanResult, batchResult)
+	}
 
 	// Populate repositories with status
 	for path, status := range batchResult.Statuses {
@@ -239,12 +468,13 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 		}
 	}
 
-	// Filter repositories based on --all flag
-	filterOpts := cli.FilterOptions{ShowAll: allFlag}
+	// Filter repositories based on --all flag. JSON documents, like NDJSON streams, always list every
+	// repository flagged with needs_attention, so consumers can tell clean repositories from unscanned ones
+	filterOpts := cli.FilterOptions{ShowAll: allFlag || formatFlag == formatJSON}
 	filteredRepos := cli.FilterRepositories(scanResult.Repositories, filterOpts)
 
 	// Check if all repos were filtered out (all clean in default mode)
-	if len(filteredRepos) == 0 && !allFlag {
+	if len(filteredRepos) == 0 && !allFlag && isTreeFormat() {
 		if !debugFlag {
 			s.Stop()
 		}
@@ -257,7 +487,7 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 	}
 
 	// Build tree structure with filtered repositories
-	root := tree.Build(targetDir, filteredRepos, nil)
+	root := tree.Build(targetDir, filteredRepos, formatOpts)
 
 	// Validate tree structure
 	if valErr := validateTree(root); valErr != nil {
@@ -269,16 +499,140 @@ func runGitree(_ *cobra.Command, args []string) error { //nolint:gocognit // Mai
 		s.Stop()
 	}
 
-	// Format and print tree
-	output := tree.Format(root, nil)
-	_, _ = fmt.Fprint(os.Stdout, output)
+	return writeOutput(root, filteredRepos, scanResult, batchResult, formatOpts)
+}
 
-	// Print summary statistics
-	printSummary(scanResult, batchResult)
+// writeOutput renders the results in the format selected by --format and writes them to stdout.
+// Tabular formats render the flat filtered repository list; the other formats render the tree.
+// The human-readable tree and tabular formats are followed by summary statistics on stderr;
+// structured formats carry the statistics in the document itself.
+func writeOutput(
+	root *models.TreeNode,
+	repos []*models.Repository,
+	scanResult *models.ScanResult,
+	batchResult *models.BatchResult,
+	formatOpts *tree.FormatOptions,
+) error {
+	switch formatFlag {
+	case formatJSON:
+		output, err := tree.FormatJSON(root, scanResult, batchResult)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(os.Stdout, output)
+	case formatHTML:
+		output, err := tree.FormatHTML(root, scanResult, batchResult)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(os.Stdout, output)
+	case formatCSV:
+		output, err := tree.FormatCSV(scanResult.RootPath, repos)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(os.Stdout, output)
+		printSummary(scanResult, batchResult)
+	case formatMarkdown:
+		_, _ = fmt.Fprint(os.Stdout, tree.FormatMarkdown(scanResult.RootPath, repos))
+		printSummary(scanResult, batchResult)
+	case formatPorcelain:
+		_, _ = fmt.Fprint(os.Stdout, tree.FormatPorcelain(scanResult.RootPath, repos))
+	case formatList:
+		_, _ = fmt.Fprint(os.Stdout, tree.FormatList(scanResult.RootPath, repos))
+	case formatTable:
+		_, _ = fmt.Fprint(os.Stdout, tree.FormatTable(root, formatOpts))
+		printSummary(scanResult, batchResult)
+	default:
+		_, _ = fmt.Fprint(os.Stdout, tree.Format(root, formatOpts))
+		printSummary(scanResult, batchResult)
+	}
 
 	return nil
 }
 
+// buildFormatOptions returns tree formatting options derived from the command-line flags.
+func buildFormatOptions() (*tree.FormatOptions, error) {
+	opts := tree.DefaultFormatOptions()
+	opts.Compact = compactFlag
+	opts.SortBy = models.SortKey(sortFlag)
+	opts.GroupBy = tree.GroupKey(groupByFlag)
+	opts.ShowAge = showAgeFlag
+
+	templateText := templateFlag
+	if templateFileFlag != "" {
+		data, err := os.ReadFile(templateFileFlag) //#nosec G304 -- template path is supplied by the user
+		if err != nil {
+			return nil, fmt.Errorf("%w: cannot read template file: %w", errInvalidFlags, err)
+		}
+		templateText = string(data)
+	}
+
+	if templateText != "" {
+		tmpl, err := tree.ParseLineTemplate(templateText)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidFlags, err)
+		}
+		opts.LineTemplate = tmpl
+	}
+
+	if formatFlag == formatTable {
+		opts.Width = terminalWidth()
+	}
+
+	return opts, nil
+}
+
+// applyConfig loads the config file, activates the theme selected by --theme or the config file,
+// and sets the trunk branches from --trunk or the config file.
+func applyConfig() error {
+	var cfg *config.Config
+	var err error
+	if configFlag != "" {
+		cfg, err = config.Load(configFlag)
+	} else {
+		cfg, err = config.LoadDefault()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidFlags, err)
+	}
+
+	theme, err := cfg.ResolveTheme(themeFlag)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidFlags, err)
+	}
+	models.SetTheme(theme)
+
+	trunk := cfg.TrunkBranches
+	if len(trunkFlag) > 0 {
+		trunk = trunkFlag
+	}
+	if err := models.SetTrunkBranches(trunk); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidFlags, err)
+	}
+
+	return nil
+}
+
+// isTreeFormat reports whether the selected output format is one of the human-readable tree layouts.
+func isTreeFormat() bool {
+	return formatFlag == formatTree || formatFlag == formatTable
+}
+
+// terminalWidth returns the width of the terminal attached to stdout. It falls back to the
+// COLUMNS environment variable and returns 0 (unlimited) when the width is unknown.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 { //#nosec G115 -- file descriptors fit in int
+		return width
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 0
+}
+
 // logValidationWarning logs a validation warning to stderr if debug mode is enabled.
 func logValidationWarning(msg string, err error) {
 	if debugFlag {
@@ -309,10 +663,16 @@ func printSummary(scanResult *models.ScanResult, batchResult *models.BatchResult
 	_, _ = fmt.Fprintf(os.Stderr, "Scanned: %d folders\n", scanResult.TotalScanned)
 	_, _ = fmt.Fprintf(os.Stderr, "Found: %d repositories\n", scanResult.TotalRepos)
 
-	if batchResult.FetchStats != nil && batchResult.FetchStats.TotalAttempted > 0 {
+	if batchResult.FetchStats != nil && (batchResult.FetchStats.TotalAttempted > 0 || batchResult.FetchStats.Fresh > 0) {
 		stats := batchResult.FetchStats
-		_, _ = fmt.Fprintf(os.Stderr, "Fetch: %d attempted, %d successful, %d skipped, %d failed\n",
-			stats.TotalAttempted, stats.Successful, stats.Skipped, stats.Failed)
+		// Fresh repositories are only counted with --fetch-max-age, so the default line stays unchanged
+		if stats.Fresh > 0 || fetchMaxAgeFlag > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Fetch: %d attempted, %d successful, %d skipped, %d fresh, %d failed\n",
+				stats.TotalAttempted, stats.Successful, stats.Skipped, stats.Fresh, stats.Failed)
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "Fetch: %d attempted, %d successful, %d skipped, %d failed\n",
+				stats.TotalAttempted, stats.Successful, stats.Skipped, stats.Failed)
+		}
 
 		// Print failed repos if any
 		if stats.Failed > 0 {
@@ -321,5 +681,10 @@ func printSummary(scanResult *models.ScanResult, batchResult *models.BatchResult
 				_, _ = fmt.Fprintf(os.Stderr, "  - %s\n", path)
 			}
 		}
+
+		if len(stats.UnreachableHosts) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "\nUnreachable hosts (remaining fetches skipped): %s\n",
+				strings.Join(stats.UnreachableHosts, ", "))
+		}
 	}
 }
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestRepo creates a repository at path with one commit and returns it with the commit hash.
+func createTestRepo(t *testing.T, path string) (*git.Repository, plumbing.Hash) {
+	t.Helper()
+
+	repo, err := git.PlainInit(path, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(path, "test.txt"), []byte("content"), 0o600))
+	_, err = worktree.Add("test.txt")
+	require.NoError(t, err)
+	commit, err := worktree.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return repo, commit
+}
+
+// createCleanRepo creates a repository at path on master, in sync with origin/master.
+func createCleanRepo(t *testing.T, path string) {
+	t.Helper()
+
+	repo, head := createTestRepo(t, path)
+	_, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/test/repo.git"}})
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/master", head)))
+}
+
+// captureStdout returns everything written to os.Stdout while fn runs.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureStderr returns everything written to os.Stderr while fn runs.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+
+	return captureFile(t, &os.Stderr, fn)
+}
+
+// captureFile returns everything written to *file while fn runs.
+func captureFile(t *testing.T, file **os.File, fn func()) string {
+	t.Helper()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	orig := *file
+	*file = writer
+	defer func() { *file = orig }()
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		done <- data
+	}()
+
+	fn()
+	require.NoError(t, writer.Close())
+
+	return string(<-done)
+}
+
+// validateFlags parses args on the root command and runs the flag checks made before scanning.
+func validateFlags(t *testing.T, args ...string) error {
+	t.Helper()
+
+	resetRootCommand()
+	t.Cleanup(resetRootCommand)
+
+	require.NoError(t, rootCmd.ParseFlags(args))
+	if err := rootCmd.ValidateFlagGroups(); err != nil {
+		return err
+	}
+
+	return handleVersionFlag(rootCmd, nil)
+}
+
+// Test output mode flags are accepted alone and select their output format.
+func TestFlags_OutputModes(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "line.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte("{{.Name}}\n"), 0o600))
+
+	tests := []struct {
+		name   string
+		args   []string
+		format string
+	}{
+		{"default", nil, formatTree},
+		{"porcelain", []string{"--porcelain"}, formatPorcelain},
+		{"list", []string{"--list"}, formatList},
+		{"compact", []string{"--compact"}, formatTree},
+		{"template", []string{"--template", "{{.Name}} {{.Branch}}"}, formatTree},
+		{"template with explicit tree format", []string{"--template", "{{.Name}}", "--format", "tree"}, formatTree},
+		{"template file", []string{"--template-file", templateFile}, formatTree},
+		{"compact table", []string{"--compact", "--format", "table"}, formatTable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, validateFlags(t, tt.args...))
+			assert.Equal(t, tt.format, formatFlag)
+		})
+	}
+}
+
+// Test conflicting output mode flags are rejected instead of silently ignored.
+func TestFlags_RejectsConflictingOutputModes(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"template with json", []string{"--template", "{{.Name}}", "--format", "json"}},
+		{"template file with table", []string{"--template-file", "line.tmpl", "--format", "table"}},
+		{"template file with csv", []string{"--template-file", "line.tmpl", "--format", "csv"}},
+		{"template and template file", []string{"--template", "{{.Name}}", "--template-file", "line.tmpl"}},
+		{"porcelain with format", []string{"--porcelain", "--format", "json"}},
+		{"porcelain with template", []string{"--porcelain", "--template", "{{.Name}}"}},
+		{"porcelain with template file", []string{"--porcelain", "--template-file", "line.tmpl"}},
+		{"list with format", []string{"--list", "--format", "tree"}},
+		{"list with porcelain", []string{"--list", "--porcelain"}},
+		{"list with template", []string{"--list", "--template", "{{.Name}}"}},
+		{"list with compact", []string{"--list", "--compact"}},
+		{"compact with group-by", []string{"--compact", "--group-by", "branch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, validateFlags(t, tt.args...))
+		})
+	}
+}
+
+// Test a template combined with another format names the conflict.
+func TestFlags_TemplateRequiresTreeFormat(t *testing.T) {
+	err := validateFlags(t, "--template", "{{.Name}}", "--format", "json")
+
+	require.ErrorIs(t, err, errInvalidFlags)
+	assert.Contains(t, err.Error(), "require --format tree")
+}
+
+// Test line templates are parsed, and checked against directories, before scanning.
+func TestBuildFormatOptions_Template(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"flat fields", "{{.Name}} {{.Branch}} {{.Ahead}}", false},
+		{"guarded git status", "{{.Name}}{{with .GitStatus}} {{.Branch}}{{end}}", false},
+		{"syntax error", "{{.Name", true},
+		{"git status on directories", "{{.GitStatus.Branch}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, validateFlags(t, "--template", tt.template))
+
+			opts, err := buildFormatOptions()
+			if tt.wantErr {
+				require.ErrorIs(t, err, errInvalidFlags)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, opts.LineTemplate)
+		})
+	}
+}
+
+// Test JSON output lists every repository, with or without --all, and flags the ones needing attention.
+func TestJSONOutput_IncludesCleanRepositories(t *testing.T) {
+	root := t.TempDir()
+	createCleanRepo(t, filepath.Join(root, "clean"))
+	createTestRepo(t, filepath.Join(root, "no-remote"))
+
+	for _, args := range [][]string{nil, {"--all"}} {
+		t.Run(fmt.Sprint(args), func(t *testing.T) {
+			var err error
+			output := captureStdout(t, func() {
+				_, err = executeRoot(t, append([]string{"--format", "json", "--no-fetch", root}, args...)...)
+			})
+			require.NoError(t, err)
+
+			var doc struct {
+				Scan struct {
+					TotalRepos int `json:"total_repos"`
+				} `json:"scan"`
+				Tree struct {
+					Children []struct {
+						Name       string `json:"name"`
+						Repository struct {
+							NeedsAttention bool `json:"needs_attention"`
+						} `json:"repository"`
+					} `json:"children"`
+				} `json:"tree"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(output), &doc))
+
+			attention := make(map[string]bool)
+			for _, child := range doc.Tree.Children {
+				attention[child.Name] = child.Repository.NeedsAttention
+			}
+			assert.Equal(t, map[string]bool{"clean": false, "no-remote": true}, attention)
+			assert.Equal(t, 2, doc.Scan.TotalRepos)
+		})
+	}
+}
+
+// Test NDJSON output streams a record for every repository found, matching the summary count.
+func TestNDJSONOutput_StreamsEveryRepository(t *testing.T) {
+	root := t.TempDir()
+	createCleanRepo(t, filepath.Join(root, "clean"))
+	createTestRepo(t, filepath.Join(root, "no-remote"))
+
+	var err error
+	output := captureStdout(t, func() {
+		_, err = executeRoot(t, "--format", "ndjson", "--no-fetch", root)
+	})
+	require.NoError(t, err)
+
+	attention := make(map[string]bool)
+	var totalRepos int
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var record struct {
+			Type         string `json:"type"`
+			RelativePath string `json:"relative_path"`
+			Repository   struct {
+				NeedsAttention bool `json:"needs_attention"`
+			} `json:"repository"`
+			Scan struct {
+				TotalRepos int `json:"total_repos"`
+			} `json:"scan"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record.Type == "summary" {
+			totalRepos = record.Scan.TotalRepos
+
+			continue
+		}
+		attention[record.RelativePath] = record.Repository.NeedsAttention
+	}
+
+	assert.Equal(t, map[string]bool{"clean": false, "no-remote": true}, attention)
+	assert.Equal(t, len(attention), totalRepos)
+}
+
+// Test the fetch summary only reports fresh repositories when --fetch-max-age can produce them.
+func TestPrintSummary_FreshCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxAge time.Duration
+		fresh  int
+		want   string
+	}{
+		{"default", 0, 0, "Fetch: 3 attempted, 2 successful, 0 skipped, 1 failed\n"},
+		{"max age set", 15 * time.Minute, 0, "Fetch: 3 attempted, 2 successful, 0 skipped, 0 fresh, 1 failed\n"},
+		{"fresh repositories", 0, 2, "Fetch: 3 attempted, 2 successful, 0 skipped, 2 fresh, 1 failed\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetRootCommand()
+			t.Cleanup(resetRootCommand)
+			fetchMaxAgeFlag = tt.maxAge
+
+			output := captureStderr(t, func() {
+				printSummary(&models.ScanResult{}, &models.BatchResult{
+					FetchStats: &models.FetchStats{TotalAttempted: 3, Successful: 2, Failed: 1, Fresh: tt.fresh},
+				})
+			})
+
+			assert.Contains(t, output, tt.want)
+		})
+	}
+}
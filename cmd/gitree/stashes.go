@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/gitstatus"
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/andreygrechin/gitree/internal/reposcan"
+	"github.com/andreygrechin/gitree/internal/tree"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra subcommand
+var stashesCmd = &cobra.Command{
+	Use:   "stashes [directory]",
+	Short: "List every stash in all repositories under a directory",
+	Long: `stashes scans the specified directory (or current directory if not provided) for Git
+repositories and lists every stash with its repository path, stash@{N} index, branch,
+creation date and message, so old stashes scattered across many repositories can be
+reviewed in one place. Remotes are not fetched.
+
+To show the tree of a directory named "stashes" rather than list stashes, pass it
+as a path: gitree ./stashes`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	RunE:          runStashes,
+}
+
+func runStashes(cmd *cobra.Command, args []string) error {
+	targetDir, err := resolveTargetDir(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+
+	scanResult, err := reposcan.Scan(ctx, reposcan.ScanOptions{RootPath: targetDir})
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+
+	repoMap := make(map[string]*models.Repository, len(scanResult.Repositories))
+	for _, repo := range scanResult.Repositories {
+		repoMap[repo.Path] = repo
+	}
+
+	// Stashes are local, so there is nothing to fetch
+	batchResult := gitstatus.ExtractBatch(ctx, repoMap, &gitstatus.ExtractOptions{
+		Timeout:        defaultTimeout,
+		MaxConcurrency: defaultMaxConcurrent,
+	})
+	for path, status := range batchResult.Statuses {
+		if repo, exists := repoMap[path]; exists {
+			repo.GitStatus = status
+		}
+	}
+
+	output := tree.FormatStashes(targetDir, scanResult.Repositories, time.Now())
+	failed := failedStashRepos(scanResult.Repositories)
+
+	switch {
+	case output != "":
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), output)
+	case len(failed) > 0:
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No stashes found in the repositories that could be read.")
+	default:
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No stashes found.")
+	}
+
+	// A repository whose status could not be read may hold stashes, so it must not go unnoticed
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "\nStatus could not be fully read, so stashes may be missing, for:")
+		for _, line := range failed {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "  - %s\n", line)
+		}
+	}
+
+	return nil
+}
+
+// failedStashRepos returns the repositories whose status extraction failed or timed out, with the
+// reason when known, sorted by path.
+func failedStashRepos(repos []*models.Repository) []string {
+	var failed []string
+	for _, repo := range repos {
+		switch {
+		case repo.GitStatus == nil:
+			failed = append(failed, repo.Path+": status extraction failed or timed out")
+		case repo.GitStatus.Error != "":
+			failed = append(failed, repo.Path+": "+repo.GitStatus.Error)
+		}
+	}
+	sort.Strings(failed)
+
+	return failed
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createRepoWithStash creates a repository at path with one commit and one stash reflog entry.
+func createRepoWithStash(t *testing.T, path string) {
+	t.Helper()
+
+	repo, head := createTestRepo(t, path)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference("refs/stash", head)))
+
+	logDir := filepath.Join(path, ".git", "logs", "refs")
+	require.NoError(t, os.MkdirAll(logDir, 0o750))
+	reflog := "0000000000000000000000000000000000000000 1111111111111111111111111111111111111111 " +
+		"Test User <test@example.com> 1700000000 +0000\tOn main: half-done parser\n"
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "stash"), []byte(reflog), 0o600))
+}
+
+// executeRoot runs the root command with args and returns its output.
+func executeRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	resetRootCommand()
+	t.Cleanup(resetRootCommand)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	})
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+
+	return out.String(), err
+}
+
+func TestStashesCommand_ListsStashes(t *testing.T) {
+	root := t.TempDir()
+	createRepoWithStash(t, filepath.Join(root, "project-a"))
+
+	output, err := executeRoot(t, "stashes", root)
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "project-a")
+	assert.Contains(t, output, "stash@{0}")
+	assert.Contains(t, output, "On main: half-done parser")
+}
+
+func TestStashesCommand_NoStashes(t *testing.T) {
+	output, err := executeRoot(t, "stashes", t.TempDir())
+
+	require.NoError(t, err)
+	assert.Equal(t, "No stashes found.\n", output)
+}
+
+// Test "stashes" as the first argument runs the subcommand, while a path scans the directory.
+func TestStashesCommand_ShadowsDirectoryName(t *testing.T) {
+	cmd, args, err := rootCmd.Find([]string{"stashes"})
+	require.NoError(t, err)
+	assert.Equal(t, stashesCmd, cmd)
+	assert.Empty(t, args)
+
+	cmd, args, err = rootCmd.Find([]string{"./stashes"})
+	require.NoError(t, err)
+	assert.Equal(t, rootCmd, cmd)
+	assert.Equal(t, []string{"./stashes"}, args)
+}
+
+// Test repositories whose status could not be read are reported instead of silently dropped.
+func TestStashesCommand_ReportsUnreadableRepositories(t *testing.T) {
+	root := t.TempDir()
+	broken := filepath.Join(root, "broken")
+	createTestRepo(t, broken)
+	require.NoError(t, os.RemoveAll(filepath.Join(broken, ".git", "objects")))
+
+	output, err := executeRoot(t, "stashes", root)
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "No stashes found in the repositories that could be read.")
+	assert.Contains(t, output, "Status could not be fully read, so stashes may be missing, for:")
+	assert.Contains(t, output, broken+": failed to get worktree status")
+}
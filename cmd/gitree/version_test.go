@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andreygrechin/gitree/internal/gitstatus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,6 +136,34 @@ func resetRootCommand() {
 	versionFlag = false
 	noColorFlag = false
 	allFlag = false
+	fetchAllFlag = false
+	fetchRemoteFlag = nil
+	pruneFlag = false
+	fetchTagsFlag = "follow"
+	fetchCurrentFlag = false
+	fetchMaxAgeFlag = 0
+	maxPerHostFlag = gitstatus.DefaultOptions().MaxConcurrencyPerHost
+	hostFailuresFlag = gitstatus.DefaultOptions().HostFailureLimit
+	formatFlag = formatTree
+	sortFlag = "name"
+	groupByFlag = ""
+	themeFlag = ""
+	configFlag = ""
+	trunkFlag = nil
+	branchesFlag = false
+	showAgeFlag = false
+	templateFlag = ""
+	templateFileFlag = ""
+	porcelainFlag = false
+	compactFlag = false
+	listFlag = false
+
+	// Forget which flags were given, so mutually exclusive flag groups are checked afresh
+	for _, name := range []string{
+		"format", "template", "template-file", "porcelain", "compact", "list", "group-by", "fetch-all", "fetch-remote",
+	} {
+		rootCmd.Flags().Lookup(name).Changed = false
+	}
 
 	// Reset command args
 	rootCmd.SetArgs([]string{})
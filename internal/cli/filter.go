@@ -9,18 +9,19 @@ type FilterOptions struct {
 
 // IsClean determines if a repository is in a clean state per FR-008.
 // A repository is considered clean if ALL of the following conditions are met:
-// 1. On main or master branch
-// 2. No uncommitted changes
-// 3. No stashes
-// 4. Has remote tracking configured
-// 5. Not ahead of remote
-// 6. Not behind remote
-// 7. Not in detached HEAD state
-// 8. No error in status extraction
+//  1. On a trunk branch: the detected default branch (from origin/HEAD) or a branch matching
+//     the configured trunk patterns (main and master unless --trunk or trunk_branches is set)
+//  2. Has remote tracking configured and its upstream still exists
+//  3. Neither ahead of nor behind remote
+//  4. No uncommitted changes and no stashes
+//  5. No merge, rebase, cherry-pick, revert, am or bisect in progress
+//  6. All submodules at their recorded commits
+//  7. No unpushed commits on other local branches (checked with --branches)
+//  8. No error in status extraction or fetch
 //
 // If any condition fails, the repository needs attention and is NOT clean.
 //
-// This function delegates to GitStatus.IsStandardStatus() to avoid code duplication.
+// This function delegates to GitStatus.IsStandardStatus(), which is authoritative for the rules above.
 func IsClean(repo *models.Repository) bool {
 	// Fail-safe: nil status = unknown = not clean (FR-009)
 	if repo == nil || repo.GitStatus == nil {
@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnknownTheme is returned when a theme name is neither built in nor defined in the config file.
+	ErrUnknownTheme = errors.New("unknown theme")
+	// ErrInvalidColor is returned when a theme color specification cannot be parsed.
+	ErrInvalidColor = errors.New("invalid color")
+)
+
+// Config is the user configuration read from config.yaml.
+//
+// Example:
+//
+//	theme: highcontrast
+//	trunk_branches: [main, develop, "release/*"]
+//	themes:
+//	  highcontrast:
+//	    base: ascii
+//	    symbols:
+//	      changes: "!"
+//	    colors:
+//	      red: magenta+bold
+//	      gray: none
+type Config struct {
+	Theme         string                 `yaml:"theme"`          // Theme used when --theme is not given
+	TrunkBranches []string               `yaml:"trunk_branches"` // Trunk branch names or globs used when --trunk is not given
+	Themes        map[string]ThemeConfig `yaml:"themes"`         // User-defined themes by name
+}
+
+// ThemeConfig defines a custom theme as overrides of a built-in base theme.
+type ThemeConfig struct {
+	Base    string        `yaml:"base"` // Built-in theme to start from (default "default")
+	Symbols SymbolsConfig `yaml:"symbols"`
+	Colors  ColorsConfig  `yaml:"colors"`
+}
+
+// SymbolsConfig overrides theme symbols; nil fields keep the base theme's value.
+type SymbolsConfig struct {
+	Upstream     *string `yaml:"upstream"`
+	Ahead        *string `yaml:"ahead"`
+	Behind       *string `yaml:"behind"`
+	BaseAhead    *string `yaml:"base_ahead"`
+	BaseBehind   *string `yaml:"base_behind"`
+	Gone         *string `yaml:"gone"`
+	Diverged     *string `yaml:"diverged"`
+	FastForward  *string `yaml:"fast_forward"`
+	NoRemote     *string `yaml:"no_remote"`
+	Stashes      *string `yaml:"stashes"`
+	Submodules   *string `yaml:"submodules"`
+	Changes      *string `yaml:"changes"`
+	Staged       *string `yaml:"staged"`
+	Modified     *string `yaml:"modified"`
+	Deleted      *string `yaml:"deleted"`
+	Renamed      *string `yaml:"renamed"`
+	Untracked    *string `yaml:"untracked"`
+	Conflicted   *string `yaml:"conflicted"`
+	Error        *string `yaml:"error"`
+	FetchError   *string `yaml:"fetch_error"`
+	Open         *string `yaml:"open"`
+	Close        *string `yaml:"close"`
+	Separator    *string `yaml:"separator"`
+	TreeBranch   *string `yaml:"tree_branch"`
+	TreeLast     *string `yaml:"tree_last"`
+	TreeVertical *string `yaml:"tree_vertical"`
+	TreeSpace    *string `yaml:"tree_space"`
+}
+
+// ColorsConfig overrides the colors of the semantic palette; nil fields keep the base theme's value.
+// Values are attribute names joined with "+", such as "blue", "hiyellow+bold" or "none".
+type ColorsConfig struct {
+	Gray   *string `yaml:"gray"`
+	Yellow *string `yaml:"yellow"`
+	Green  *string `yaml:"green"`
+	Red    *string `yaml:"red"`
+}
+
+// DefaultPath returns the default config file location:
+// $XDG_CONFIG_HOME/gitree/config.yaml, or ~/.config/gitree/config.yaml.
+func DefaultPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %w", err)
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+
+	return filepath.Join(configHome, "gitree", "config.yaml"), nil
+}
+
+// Load reads and parses the config file at path. Unknown keys are rejected.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path) //#nosec G304 -- config path is supplied by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// LoadDefault reads the config file at DefaultPath. A missing file yields an empty configuration.
+func LoadDefault() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return &Config{}, nil //nolint:nilerr // Without a home directory there is no default config
+	}
+
+	cfg, err := Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+
+	return cfg, err
+}
+
+// ResolveTheme returns the theme with the given name. An empty name selects the configured
+// theme, falling back to the built-in default theme. Themes defined in the config file take
+// precedence over built-in themes of the same name.
+func (c *Config) ResolveTheme(name string) (models.Theme, error) {
+	if name == "" {
+		name = c.Theme
+	}
+	if name == "" {
+		name = models.ThemeDefault
+	}
+
+	themeCfg, exists := c.Themes[name]
+	if !exists {
+		theme, ok := models.BuiltinTheme(name)
+		if !ok {
+			return models.Theme{}, fmt.Errorf("%w: %q (built-in themes: %s)",
+				ErrUnknownTheme, name, strings.Join(models.BuiltinThemeNames(), ", "))
+		}
+
+		return theme, nil
+	}
+
+	baseName := themeCfg.Base
+	if baseName == "" {
+		baseName = models.ThemeDefault
+	}
+
+	theme, ok := models.BuiltinTheme(baseName)
+	if !ok {
+		return models.Theme{}, fmt.Errorf("%w: base %q of theme %q", ErrUnknownTheme, baseName, name)
+	}
+	theme.Name = name
+
+	themeCfg.Symbols.apply(&theme.Symbols)
+	if err := themeCfg.Colors.apply(&theme.Palette); err != nil {
+		return models.Theme{}, fmt.Errorf("theme %q: %w", name, err)
+	}
+
+	return theme, nil
+}
+
+// apply copies the configured symbols over s.
+func (sc SymbolsConfig) apply(s *models.Symbols) {
+	overrides := []struct {
+		value  *string
+		target *string
+	}{
+		{sc.Upstream, &s.Upstream},
+		{sc.Ahead, &s.Ahead},
+		{sc.Behind, &s.Behind},
+		{sc.BaseAhead, &s.BaseAhead},
+		{sc.BaseBehind, &s.BaseBehind},
+		{sc.Gone, &s.Gone},
+		{sc.Diverged, &s.Diverged},
+		{sc.FastForward, &s.FastForward},
+		{sc.NoRemote, &s.NoRemote},
+		{sc.Stashes, &s.Stashes},
+		{sc.Submodules, &s.Submodules},
+		{sc.Changes, &s.Changes},
+		{sc.Staged, &s.Staged},
+		{sc.Modified, &s.Modified},
+		{sc.Deleted, &s.Deleted},
+		{sc.Renamed, &s.Renamed},
+		{sc.Untracked, &s.Untracked},
+		{sc.Conflicted, &s.Conflicted},
+		{sc.Error, &s.Error},
+		{sc.FetchError, &s.FetchError},
+		{sc.Open, &s.Open},
+		{sc.Close, &s.Close},
+		{sc.Separator, &s.Separator},
+		{sc.TreeBranch, &s.TreeBranch},
+		{sc.TreeLast, &s.TreeLast},
+		{sc.TreeVertical, &s.TreeVertical},
+		{sc.TreeSpace, &s.TreeSpace},
+	}
+
+	for _, o := range overrides {
+		if o.value != nil {
+			*o.target = *o.value
+		}
+	}
+}
+
+// apply parses the configured colors into p.
+func (cc ColorsConfig) apply(p *models.Palette) error {
+	overrides := []struct {
+		value  *string
+		target *[]color.Attribute
+	}{
+		{cc.Gray, &p.Gray},
+		{cc.Yellow, &p.Yellow},
+		{cc.Green, &p.Green},
+		{cc.Red, &p.Red},
+	}
+
+	for _, o := range overrides {
+		if o.value == nil {
+			continue
+		}
+
+		attrs, err := ParseColor(*o.value)
+		if err != nil {
+			return err
+		}
+		*o.target = attrs
+	}
+
+	return nil
+}
+
+// colorAttributes maps color specification names to terminal attributes.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var colorAttributes = map[string]color.Attribute{
+	"black":     color.FgBlack,
+	"red":       color.FgRed,
+	"green":     color.FgGreen,
+	"yellow":    color.FgYellow,
+	"blue":      color.FgBlue,
+	"magenta":   color.FgMagenta,
+	"cyan":      color.FgCyan,
+	"white":     color.FgWhite,
+	"hiblack":   color.FgHiBlack,
+	"hired":     color.FgHiRed,
+	"higreen":   color.FgHiGreen,
+	"hiyellow":  color.FgHiYellow,
+	"hiblue":    color.FgHiBlue,
+	"himagenta": color.FgHiMagenta,
+	"hicyan":    color.FgHiCyan,
+	"hiwhite":   color.FgHiWhite,
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"reverse":   color.ReverseVideo,
+}
+
+// ParseColor parses a color specification made of attribute names joined with "+",
+// for example "red", "hiblack+bold" or "blue+underline". "none" or an empty string
+// means no color.
+func ParseColor(spec string) ([]color.Attribute, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "" || spec == "none" {
+		return nil, nil
+	}
+
+	var attrs []color.Attribute
+	for _, name := range strings.Split(spec, "+") {
+		attr, ok := colorAttributes[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in %q", ErrInvalidColor, name, spec)
+		}
+		attrs = append(attrs, attr)
+	}
+
+	return attrs, nil
+}
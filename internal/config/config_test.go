@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes a config file into a temporary directory and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// Test Load parses custom themes and ResolveTheme applies them over the base theme.
+func TestLoad_CustomTheme(t *testing.T) {
+	path := writeConfig(t, `
+theme: mine
+themes:
+  mine:
+    base: ascii
+    symbols:
+      changes: "!"
+      fast_forward: "=>"
+      open: ""
+      close: ""
+    colors:
+      red: magenta+bold
+      gray: none
+`)
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	theme, err := cfg.ResolveTheme("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "mine", theme.Name)
+	assert.Equal(t, "!", theme.Symbols.Changes)
+	assert.Equal(t, "=>", theme.Symbols.FastForward)
+	assert.Equal(t, ">", theme.Symbols.Ahead, "unset symbols come from the base theme")
+	assert.Empty(t, theme.Symbols.Open)
+	assert.Empty(t, theme.Symbols.Close)
+	assert.Equal(t, "|", theme.Symbols.Separator)
+	assert.Equal(t, []color.Attribute{color.FgMagenta, color.Bold}, theme.Palette.Red)
+	assert.Empty(t, theme.Palette.Gray)
+	assert.Equal(t, []color.Attribute{color.FgGreen, color.Bold}, theme.Palette.Green)
+}
+
+// Test Load parses the trunk branch list.
+func TestLoad_TrunkBranches(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "trunk_branches: [develop, \"release/*\"]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"develop", "release/*"}, cfg.TrunkBranches)
+}
+
+// Test ResolveTheme selects built-in themes and reports unknown names.
+func TestResolveTheme_Builtin(t *testing.T) {
+	cfg := &Config{}
+
+	theme, err := cfg.ResolveTheme("")
+	require.NoError(t, err)
+	assert.Equal(t, models.ThemeDefault, theme.Name)
+
+	theme, err = cfg.ResolveTheme(models.ThemeWords)
+	require.NoError(t, err)
+	assert.Equal(t, "dirty", theme.Symbols.Changes)
+
+	_, err = cfg.ResolveTheme("neon")
+	require.ErrorIs(t, err, ErrUnknownTheme)
+
+	cfg.Themes = map[string]ThemeConfig{"broken": {Base: "neon"}}
+	_, err = cfg.ResolveTheme("broken")
+	require.ErrorIs(t, err, ErrUnknownTheme)
+}
+
+// Test Load rejects unknown keys and invalid colors are reported on resolve.
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load(writeConfig(t, "thme: words\n"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	cfg, err := Load(writeConfig(t, "themes:\n  bad:\n    colors:\n      red: purple\n"))
+	require.NoError(t, err)
+	_, err = cfg.ResolveTheme("bad")
+	require.ErrorIs(t, err, ErrInvalidColor)
+
+	cfg, err = Load(writeConfig(t, ""))
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Theme)
+}
+
+// Test LoadDefault reads $XDG_CONFIG_HOME/gitree/config.yaml and tolerates a missing file.
+func TestLoadDefault(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	cfg, err := LoadDefault()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Theme)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(configHome, "gitree"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(configHome, "gitree", "config.yaml"), []byte("theme: ascii\n"), 0o600))
+
+	cfg, err = LoadDefault()
+	require.NoError(t, err)
+	assert.Equal(t, models.ThemeASCII, cfg.Theme)
+}
+
+// Test ParseColor accepts attribute combinations and rejects unknown names.
+func TestParseColor(t *testing.T) {
+	attrs, err := ParseColor(" HiYellow+bold ")
+	require.NoError(t, err)
+	assert.Equal(t, []color.Attribute{color.FgHiYellow, color.Bold}, attrs)
+
+	attrs, err = ParseColor("none")
+	require.NoError(t, err)
+	assert.Empty(t, attrs)
+
+	_, err = ParseColor("red+sparkly")
+	require.ErrorIs(t, err, ErrInvalidColor)
+}
@@ -0,0 +1,277 @@
+package gitstatus
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphfmt "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// Reachability flags of a commit during the ahead/behind walk.
+const (
+	reachableFromLeft  uint8 = 1 << iota // Ancestor of the left tip (e.g. HEAD)
+	reachableFromRight                   // Ancestor of the right tip (e.g. the upstream)
+	reachableFromBoth  = reachableFromLeft | reachableFromRight
+)
+
+// commitGraph counts commits between two tips. It reads the commit-graph file
+// (objects/info/commit-graph or a split commit-graph chain) when the repository has one and
+// decodes commit objects otherwise.
+type commitGraph struct {
+	index  commitgraph.CommitNodeIndex
+	closer io.Closer
+}
+
+// newCommitGraph returns a commitGraph for repo. A missing or unreadable commit-graph file is
+// not an error: commits are then read from the object store.
+func newCommitGraph(repo *git.Repository) *commitGraph {
+	if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		if graphIndex, err := openCommitGraphIndex(storage.Filesystem()); err == nil {
+			return &commitGraph{
+				index:  commitgraph.NewGraphCommitNodeIndex(graphIndex, repo.Storer),
+				closer: graphIndex,
+			}
+		}
+	}
+
+	return &commitGraph{index: commitgraph.NewObjectCommitNodeIndex(repo.Storer)}
+}
+
+// openCommitGraphIndex opens the commit-graph of the git directory fs. Every file of a split
+// chain is checked first, because go-git does not handle a chain whose first file is missing.
+func openCommitGraphIndex(fs billy.Filesystem) (commitgraphfmt.Index, error) {
+	if _, err := fs.Stat(path.Join("objects", "info", "commit-graph")); err != nil {
+		chainPath := path.Join("objects", "info", "commit-graphs", "commit-graph-chain")
+		chainFile, err := fs.Open(chainPath)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := commitgraphfmt.OpenChainFile(chainFile)
+		_ = chainFile.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			return nil, os.ErrNotExist
+		}
+		for _, hash := range hashes {
+			if _, err := fs.Stat(path.Join("objects", "info", "commit-graphs", "graph-"+hash+".graph")); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return commitgraphfmt.OpenChainOrFileIndex(fs)
+}
+
+// Close releases the commit-graph file.
+func (g *commitGraph) Close() {
+	if g.closer != nil {
+		_ = g.closer.Close()
+	}
+}
+
+// aheadBehind returns the number of commits reachable from left but not right (ahead) and
+// from right but not left (behind). Both tips are walked at once, newest generation first,
+// and the walk stops as soon as every queued commit is reachable from both sides, so only the
+// commits between the tips and their merge bases are read. Generation numbers make the counts
+// exact. Commits outside the commit-graph are ordered by commit date and then by discovery,
+// as git does without one; a commit found to be reachable from the other side after it was
+// walked passes that on to its ancestors, which corrects most effects of clock skew.
+func (g *commitGraph) aheadBehind(left, right plumbing.Hash) (ahead, behind int, err error) {
+	if left == right {
+		return 0, 0, nil
+	}
+
+	return g.count([]plumbing.Hash{left}, []plumbing.Hash{right}, reachableFromBoth)
+}
+
+// unpushed returns the number of commits reachable from tip but from none of remoteTips.
+// Only the commits below remoteTips that are needed to settle tip's side are walked.
+func (g *commitGraph) unpushed(tip plumbing.Hash, remoteTips []plumbing.Hash) (int, error) {
+	ahead, _, err := g.count([]plumbing.Hash{tip}, remoteTips, reachableFromLeft)
+
+	return ahead, err
+}
+
+// count walks lefts and rights together and returns the number of commits reachable only
+// from the left tips and only from the right tips. The walk continues while a queued commit
+// reachable from a side in track is not yet known to be reachable from both, so the count of
+// a side not in track may be incomplete.
+func (g *commitGraph) count(lefts, rights []plumbing.Hash, track uint8) (left, right int, err error) {
+	walk := &graphWalk{index: g.index, track: track, commits: make(map[plumbing.Hash]*walkCommit)}
+	for _, tip := range lefts {
+		if err := walk.push(tip, reachableFromLeft); err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, tip := range rights {
+		if err := walk.push(tip, reachableFromRight); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	for walk.pending > 0 {
+		commit, _ := heap.Pop(&walk.queue).(*walkCommit)
+		commit.queued = false
+		if walk.isPending(commit.flags) {
+			walk.pending--
+		}
+
+		commit.walked = true
+		for _, parent := range commit.node.ParentHashes() {
+			// Shallow clones lack the parents of their boundary commits
+			if err := walk.push(parent, commit.flags); err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+				return 0, 0, err
+			}
+		}
+	}
+
+	// Commits still queued are reachable from both sides or from no tracked side
+	for _, commit := range walk.commits {
+		switch commit.flags {
+		case reachableFromLeft:
+			left++
+		case reachableFromRight:
+			right++
+		}
+	}
+
+	return left, right, nil
+}
+
+// graphWalk is the state of one ahead/behind walk.
+type graphWalk struct {
+	index      commitgraph.CommitNodeIndex
+	track      uint8 // Sides whose commits must be settled before the walk stops
+	queue      walkQueue
+	commits    map[plumbing.Hash]*walkCommit // Every commit seen so far
+	pending    int                           // Queued commits of a tracked side not yet reachable from both tips
+	discovered int                           // Number of commits seen so far
+}
+
+// walkCommit is a commit seen during the walk.
+type walkCommit struct {
+	node       commitgraph.CommitNode
+	flags      uint8 // Tips the commit is known to be reachable from
+	queued     bool  // Waiting in the queue
+	walked     bool  // Parents have been pushed
+	generation uint64
+	when       int64
+	order      int // Discovery order, so commits with equal dates are walked first in, first out
+}
+
+// push marks the commit with the given hash as reachable from flags, queueing it when it is
+// seen for the first time.
+func (w *graphWalk) push(hash plumbing.Hash, flags uint8) error {
+	if commit, seen := w.commits[hash]; seen {
+		w.mark(commit, flags)
+
+		return nil
+	}
+
+	node, err := w.index.Get(hash)
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", hash, err)
+	}
+
+	w.discovered++
+	commit := &walkCommit{
+		node:       node,
+		flags:      flags,
+		queued:     true,
+		generation: node.Generation(),
+		when:       node.CommitTime().Unix(),
+		order:      w.discovered,
+	}
+	w.commits[hash] = commit
+	heap.Push(&w.queue, commit)
+	if w.isPending(flags) {
+		w.pending++
+	}
+
+	return nil
+}
+
+// mark adds flags to a commit that was already seen and, if it was walked, to its ancestors
+// that were seen through it.
+func (w *graphWalk) mark(commit *walkCommit, flags uint8) {
+	type markItem struct {
+		commit *walkCommit
+		flags  uint8
+	}
+
+	stack := []markItem{{commit, flags}}
+	for len(stack) > 0 {
+		item := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		combined := item.commit.flags | item.flags
+		if combined == item.commit.flags {
+			continue
+		}
+		if item.commit.queued && w.isPending(item.commit.flags) && !w.isPending(combined) {
+			w.pending--
+		}
+		item.commit.flags = combined
+
+		if !item.commit.walked {
+			continue
+		}
+		for _, parentHash := range item.commit.node.ParentHashes() {
+			if parent, seen := w.commits[parentHash]; seen {
+				stack = append(stack, markItem{parent, combined})
+			}
+		}
+	}
+}
+
+// isPending reports whether a queued commit with flags keeps the walk going: it is reachable
+// from a tracked side but not yet from both.
+func (w *graphWalk) isPending(flags uint8) bool {
+	return flags != reachableFromBoth && flags&w.track != 0
+}
+
+// walkQueue is a max-heap of commits by generation number, then by commit date, then by
+// reverse discovery order.
+type walkQueue []*walkCommit
+
+func (q walkQueue) Len() int { return len(q) }
+
+func (q walkQueue) Less(i, j int) bool {
+	if q[i].generation != q[j].generation {
+		return q[i].generation > q[j].generation
+	}
+	if q[i].when != q[j].when {
+		return q[i].when > q[j].when
+	}
+
+	return q[i].order < q[j].order
+}
+
+func (q walkQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *walkQueue) Push(x any) {
+	if commit, ok := x.(*walkCommit); ok {
+		*q = append(*q, commit)
+	}
+}
+
+func (q *walkQueue) Pop() any {
+	old := *q
+	n := len(old)
+	commit := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+
+	return commit
+}
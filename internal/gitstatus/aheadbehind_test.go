@@ -0,0 +1,253 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphfmt "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHistory builds commit graphs with explicit parents and dates, recording the
+// generation numbers a commit-graph file would hold.
+type testHistory struct {
+	t     *testing.T
+	path  string
+	repo  *git.Repository
+	index *commitgraphfmt.MemoryIndex
+	gens  map[plumbing.Hash]uint64
+	clock time.Time
+}
+
+// newTestHistory creates an empty repository for a test history.
+func newTestHistory(t *testing.T) *testHistory {
+	t.Helper()
+
+	path := t.TempDir()
+	repo, err := git.PlainInit(path, false)
+	require.NoError(t, err)
+
+	return &testHistory{
+		t:     t,
+		path:  path,
+		repo:  repo,
+		index: commitgraphfmt.NewMemoryIndex(),
+		gens:  make(map[plumbing.Hash]uint64),
+		clock: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// commit stores a commit with the given parents, one minute after the previous one.
+func (h *testHistory) commit(parents ...plumbing.Hash) plumbing.Hash {
+	h.clock = h.clock.Add(time.Minute)
+
+	return h.commitAt(h.clock, parents...)
+}
+
+// commitAt stores a commit with the given parents and commit date.
+func (h *testHistory) commitAt(when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+	h.t.Helper()
+
+	sig := object.Signature{Name: "Test User", Email: "test@example.com", When: when}
+	commit := &object.Commit{Author: sig, Committer: sig, Message: "commit", ParentHashes: parents}
+	obj := h.repo.Storer.NewEncodedObject()
+	require.NoError(h.t, commit.Encode(obj))
+	hash, err := h.repo.Storer.SetEncodedObject(obj)
+	require.NoError(h.t, err)
+
+	generation := uint64(1)
+	for _, parent := range parents {
+		generation = max(generation, h.gens[parent]+1)
+	}
+	h.gens[hash] = generation
+	h.index.Add(hash, &commitgraphfmt.CommitData{ParentHashes: parents, Generation: generation, When: when})
+
+	return hash
+}
+
+// chain stores n commits on top of parent and returns the last one.
+func (h *testHistory) chain(parent plumbing.Hash, n int) plumbing.Hash {
+	for range n {
+		if parent.IsZero() {
+			parent = h.commit()
+		} else {
+			parent = h.commit(parent)
+		}
+	}
+
+	return parent
+}
+
+// writeCommitGraph writes objects/info/commit-graph for every commit stored so far.
+func (h *testHistory) writeCommitGraph() {
+	h.t.Helper()
+
+	dir := filepath.Join(h.path, ".git", "objects", "info")
+	require.NoError(h.t, os.MkdirAll(dir, 0o750))
+	file, err := os.Create(filepath.Join(dir, "commit-graph"))
+	require.NoError(h.t, err)
+	defer func() { require.NoError(h.t, file.Close()) }()
+
+	require.NoError(h.t, commitgraphfmt.NewEncoder(file).Encode(h.index))
+}
+
+// Test aheadBehind counts exactly on linear, diverged, merged and unrelated histories.
+func TestCommitGraphAheadBehind(t *testing.T) {
+	tests := []struct {
+		name          string
+		build         func(h *testHistory) (left, right plumbing.Hash)
+		ahead, behind int
+	}{
+		{
+			name: "same commit",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				tip := h.chain(plumbing.ZeroHash, 3)
+
+				return tip, tip
+			},
+		},
+		{
+			name: "ahead only",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				base := h.chain(plumbing.ZeroHash, 3)
+
+				return h.chain(base, 4), base
+			},
+			ahead: 4,
+		},
+		{
+			name: "diverged far beyond the old 100-commit limit",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				base := h.chain(plumbing.ZeroHash, 10)
+
+				return h.chain(base, 150), h.chain(base, 1200)
+			},
+			ahead:  150,
+			behind: 1200,
+		},
+		{
+			name: "right merged into left",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				base := h.chain(plumbing.ZeroHash, 2)
+				left := h.chain(base, 2)
+				right := h.chain(base, 3)
+				merge := h.commit(left, right)
+
+				return h.chain(merge, 1), h.chain(right, 1)
+			},
+			ahead:  4, // Two left commits, the merge and the commit after it
+			behind: 1,
+		},
+		{
+			name: "unrelated histories",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				return h.chain(plumbing.ZeroHash, 5), h.chain(plumbing.ZeroHash, 7)
+			},
+			ahead:  5,
+			behind: 7,
+		},
+		{
+			name: "skewed commit dates",
+			build: func(h *testHistory) (plumbing.Hash, plumbing.Hash) {
+				base := h.chain(plumbing.ZeroHash, 2)
+				// A commit dated far in the past is walked after its ancestors unless generation numbers are known
+				old := h.commitAt(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), base)
+				right := h.chain(old, 2)
+				left := h.chain(right, 3)
+
+				return left, h.commit(base)
+			},
+			ahead:  6,
+			behind: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, withCommitGraph := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/commit-graph=%t", tt.name, withCommitGraph), func(t *testing.T) {
+				h := newTestHistory(t)
+				left, right := tt.build(h)
+				if withCommitGraph {
+					h.writeCommitGraph()
+				}
+
+				graph := newCommitGraph(h.repo)
+				defer graph.Close()
+				assert.Equal(t, withCommitGraph, graph.closer != nil, "the commit-graph file is used when present")
+
+				ahead, behind, err := graph.aheadBehind(left, right)
+				require.NoError(t, err)
+				assert.Equal(t, tt.ahead, ahead, "ahead")
+				assert.Equal(t, tt.behind, behind, "behind")
+
+				// Swapping the tips swaps the counts
+				ahead, behind, err = graph.aheadBehind(right, left)
+				require.NoError(t, err)
+				assert.Equal(t, tt.behind, ahead, "ahead (swapped)")
+				assert.Equal(t, tt.ahead, behind, "behind (swapped)")
+			})
+		}
+	}
+}
+
+// Test aheadBehind reports a missing tip commit.
+func TestCommitGraphAheadBehind_MissingCommit(t *testing.T) {
+	h := newTestHistory(t)
+	tip := h.chain(plumbing.ZeroHash, 2)
+
+	graph := newCommitGraph(h.repo)
+	defer graph.Close()
+
+	_, _, err := graph.aheadBehind(tip, plumbing.NewHash("1111111111111111111111111111111111111111"))
+	require.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
+
+// Test unpushed counts the commits of a tip that are on none of the remote tips.
+func TestCommitGraphUnpushed(t *testing.T) {
+	for _, withCommitGraph := range []bool{false, true} {
+		t.Run(fmt.Sprintf("commit-graph=%t", withCommitGraph), func(t *testing.T) {
+			h := newTestHistory(t)
+			base := h.chain(plumbing.ZeroHash, 5)
+			main := h.chain(base, 20)
+			merged := h.chain(base, 3)
+			release := h.commit(main, merged)
+			local := h.chain(merged, 2)
+			unrelated := h.chain(plumbing.ZeroHash, 4)
+			if withCommitGraph {
+				h.writeCommitGraph()
+			}
+
+			graph := newCommitGraph(h.repo)
+			defer graph.Close()
+
+			remoteTips := []plumbing.Hash{main, release}
+			tests := []struct {
+				name     string
+				tip      plumbing.Hash
+				unpushed int
+			}{
+				{"remote tip", main, 0},
+				{"merged into another remote branch", merged, 0},
+				{"commits past a merged point", local, 2},
+				{"unrelated history", unrelated, 4},
+			}
+			for _, tt := range tests {
+				unpushed, err := graph.unpushed(tt.tip, remoteTips)
+				require.NoError(t, err, tt.name)
+				assert.Equal(t, tt.unpushed, unpushed, tt.name)
+			}
+
+			// Without remote-tracking branches every commit is unpushed
+			unpushed, err := graph.unpushed(local, nil)
+			require.NoError(t, err)
+			assert.Equal(t, 10, unpushed)
+		})
+	}
+}
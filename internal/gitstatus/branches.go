@@ -0,0 +1,96 @@
+package gitstatus
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// extractBranches records every local branch with its upstream, the number of its commits
+// that are on no remote-tracking branch and the date of its last commit. A commit counts as
+// pushed once any remote-tracking branch contains it, so work merged into origin/main is not
+// reported on the branch it was made on.
+func extractBranches(repo *git.Repository, graph *commitGraph, status *models.GitStatus) error {
+	remoteTips, err := remoteTrackingTips(repo)
+	if err != nil {
+		return err
+	}
+
+	var headName plumbing.ReferenceName
+	if head, err := repo.Head(); err == nil {
+		headName = head.Name()
+	}
+
+	iter, err := repo.Branches()
+	if err != nil {
+		return fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var branches []models.BranchStatus
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		branch := models.BranchStatus{Name: ref.Name().Short(), IsHead: ref.Name() == headName}
+
+		if upstream, configured, err := resolveUpstream(repo, ref.Name()); err == nil {
+			_, err := repo.Reference(upstream, true)
+			switch {
+			case err == nil:
+				branch.Upstream = upstream.Short()
+			case configured && errors.Is(err, plumbing.ErrReferenceNotFound):
+				branch.Upstream = upstream.Short()
+				branch.UpstreamGone = true
+			}
+		}
+
+		unpushed, err := graph.unpushed(ref.Hash(), remoteTips)
+		if err != nil {
+			return fmt.Errorf("failed to count unpushed commits on %s: %w", branch.Name, err)
+		}
+		branch.Unpushed = unpushed
+
+		if node, err := graph.index.Get(ref.Hash()); err == nil {
+			branch.LastCommitDate = node.CommitTime()
+		}
+
+		branches = append(branches, branch)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name < branches[j].Name })
+	status.Branches = branches
+
+	return nil
+}
+
+// remoteTrackingTips returns the commits the remote-tracking branches point to, once each.
+func remoteTrackingTips(repo *git.Repository) ([]plumbing.Hash, error) {
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	seen := make(map[plumbing.Hash]bool)
+	var tips []plumbing.Hash
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Symbolic references such as origin/HEAD repeat another remote-tracking branch
+		if !ref.Name().IsRemote() || ref.Type() != plumbing.HashReference || seen[ref.Hash()] {
+			return nil
+		}
+		seen[ref.Hash()] = true
+		tips = append(tips, ref.Hash())
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	return tips, nil
+}
@@ -0,0 +1,71 @@
+package gitstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test Extract() reports every local branch with its upstream and unpushed commits when requested.
+func TestExtract_ReportsLocalBranches(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-remote")
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	initial := head.Hash()
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	// master is pushed
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), initial))
+	require.NoError(t, err)
+
+	// merged has no upstream, but its commit reached origin/main
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: initial, Branch: plumbing.NewBranchReferenceName("merged"), Create: true})
+	require.NoError(t, err)
+	mergedHead := commitTestFile(t, repoPath, "merged.txt", "merged")
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "main"), mergedHead))
+	require.NoError(t, err)
+
+	// feature has two commits that exist only locally
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: initial, Branch: plumbing.NewBranchReferenceName("feature"), Create: true})
+	require.NoError(t, err)
+	commitTestFile(t, repoPath, "feature1.txt", "one")
+	commitTestFile(t, repoPath, "feature2.txt", "two")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Nil(t, status.Branches, "branches are only examined on request")
+
+	opts := DefaultOptions()
+	opts.Branches = true
+	status, err = Extract(context.Background(), repoPath, opts, []gitignore.Pattern{})
+	require.NoError(t, err)
+	require.Len(t, status.Branches, 3)
+
+	feature, master, merged := status.Branches[0], status.Branches[1], status.Branches[2]
+	assert.Equal(t, "feature", feature.Name)
+	assert.Empty(t, feature.Upstream)
+	assert.Equal(t, 2, feature.Unpushed)
+	assert.True(t, feature.IsHead)
+
+	assert.Equal(t, "master", master.Name)
+	assert.Equal(t, "origin/master", master.Upstream)
+	assert.Zero(t, master.Unpushed)
+	assert.False(t, master.IsHead)
+
+	assert.Equal(t, "merged", merged.Name)
+	assert.Empty(t, merged.Upstream)
+	assert.Zero(t, merged.Unpushed, "commits on any remote-tracking branch are pushed")
+
+	for _, branch := range status.Branches {
+		assert.False(t, branch.LastCommitDate.IsZero(), branch.Name)
+	}
+	assert.Equal(t, 1, status.UnpushedBranches())
+}
@@ -5,15 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/andreygrechin/gitree/internal/models"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 const (
 	originRemote       = "origin"
+	fetchHeadFile      = "FETCH_HEAD"        // Written by git fetch in the git directory
+	fetchStampFile     = "gitree-last-fetch" // Written by gitree in the common git directory after fetching
 	baseBackoffDelay   = 500 * time.Millisecond
 	maxBackoffDelay    = 10 * time.Second
 	backoffExponentTwo = 2 // Base for exponential backoff calculation.
@@ -27,37 +34,151 @@ type FetchResult struct {
 	Retries int
 }
 
-// fetchFromOrigin fetches from the origin remote with retry logic.
-// Returns FetchResult indicating success, skip (no origin), or failure with error.
-func fetchFromOrigin(ctx context.Context, repoPath string, opts *ExtractOptions) *FetchResult {
+// FetchTagsPolicy selects the tags downloaded by a fetch.
+type FetchTagsPolicy string
+
+const (
+	FetchTagsFollow FetchTagsPolicy = "follow" // Tags pointing into the fetched history, as git fetch does (default)
+	FetchTagsAll    FetchTagsPolicy = "all"    // Every tag of the remote
+	FetchTagsNone   FetchTagsPolicy = "none"   // No tags
+)
+
+// FetchTagsPolicies returns the supported tag policies.
+func FetchTagsPolicies() []FetchTagsPolicy {
+	return []FetchTagsPolicy{FetchTagsFollow, FetchTagsAll, FetchTagsNone}
+}
+
+// tagMode returns the go-git tag mode of the policy.
+func (p FetchTagsPolicy) tagMode() git.TagMode {
+	switch p {
+	case FetchTagsAll:
+		return git.AllTags
+	case FetchTagsNone:
+		return git.NoTags
+	default:
+		return git.TagFollowing
+	}
+}
+
+// fetchRemotes fetches the remotes selected by opts (origin unless FetchAllRemotes or
+// FetchRemotes is set) with retry logic, within the per-host limits of hosts (nil for none).
+// Every selected remote is fetched even if another fails. Returns FetchResult indicating
+// success, skip (no selected remote), or failure with the errors of the failed remotes.
+func fetchRemotes(ctx context.Context, repoPath string, opts *ExtractOptions, hosts *hostLimiter) *FetchResult {
 	result := &FetchResult{}
 
 	// Open repository
-	repo, err := git.PlainOpen(repoPath)
+	repo, err := openRepository(repoPath)
 	if err != nil {
 		result.Error = fmt.Errorf("failed to open repository: %w", err)
 
 		return result
 	}
 
-	// Check if origin remote exists
-	remote, err := repo.Remote(originRemote)
+	remotes, err := selectFetchRemotes(repo, opts)
 	if err != nil {
-		// No origin remote - skip fetch, not an error
+		result.Error = err
+
+		return result
+	}
+	if len(remotes) == 0 {
+		// No remote to fetch - skip fetch, not an error
 		result.Skipped = true
 
 		return result
 	}
 
-	// Verify remote has URLs configured
-	remoteConfig := remote.Config()
-	if len(remoteConfig.URLs) == 0 {
-		result.Skipped = true
+	var errs []error
+	for _, remote := range remotes {
+		retries, fetchErr := fetchRemote(ctx, repo, repoPath, remote.Config(), opts, hosts)
+		result.Retries += retries
+
+		// Stop when canceled, reporting the context error itself
+		if ctx.Err() != nil {
+			result.Error = ctx.Err()
+
+			return result
+		}
+		if fetchErr != nil {
+			errs = append(errs, fetchErr)
+		}
+	}
+
+	if len(errs) > 0 {
+		result.Error = errors.Join(errs...)
 
 		return result
 	}
 
-	remoteURL := remoteConfig.URLs[0]
+	result.Success = true
+
+	return result
+}
+
+// selectFetchRemotes returns the remotes of repo to fetch, in fetch order: every remote by name
+// if opts.FetchAllRemotes is set, the remotes named in opts.FetchRemotes that repo has, or
+// origin. Remotes without URLs are left out.
+func selectFetchRemotes(repo *git.Repository, opts *ExtractOptions) ([]*git.Remote, error) {
+	var remotes []*git.Remote
+
+	switch {
+	case opts.FetchAllRemotes:
+		all, err := repo.Remotes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list remotes: %w", err)
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].Config().Name < all[j].Config().Name })
+		remotes = all
+	default:
+		names := opts.FetchRemotes
+		if len(names) == 0 {
+			names = []string{originRemote}
+		}
+
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			// A remote missing from this repository is skipped, so one list fits forks and clones alike
+			remote, err := repo.Remote(name)
+			if err != nil {
+				continue
+			}
+			remotes = append(remotes, remote)
+		}
+	}
+
+	withURLs := remotes[:0]
+	for _, remote := range remotes {
+		if len(remote.Config().URLs) > 0 {
+			withURLs = append(withURLs, remote)
+		}
+	}
+
+	return withURLs, nil
+}
+
+// fetchRemote fetches one remote, retrying failed attempts with backoff. Attempts stop early
+// once hosts reports the remote's host unreachable. It returns the number of retries made and
+// the error of the last attempt.
+func fetchRemote(
+	ctx context.Context,
+	repo *git.Repository,
+	repoPath string,
+	remoteCfg *config.RemoteConfig,
+	opts *ExtractOptions,
+	hosts *hostLimiter,
+) (int, error) {
+	host := remoteHost(remoteCfg.URLs[0])
+
+	// Narrow the fetch to the current branch when asked; a detached HEAD fetches everything
+	var refSpecs []config.RefSpec
+	if opts.FetchCurrentBranch {
+		refSpecs = currentBranchRefSpecs(repo, remoteCfg)
+	}
 
 	// Perform fetch with retries
 	maxRetries := opts.FetchRetries
@@ -65,48 +186,61 @@ func fetchFromOrigin(ctx context.Context, repoPath string, opts *ExtractOptions)
 		maxRetries = defaultFetchRetries
 	}
 
+	var retries int
 	var lastErr error
 	for attempt := range maxRetries {
-		result.Retries = attempt
+		retries = attempt
 
 		// Check context before each attempt
 		select {
 		case <-ctx.Done():
-			result.Error = ctx.Err()
-
-			return result
+			return retries, ctx.Err()
 		default:
 		}
 
-		// Calculate backoff delay for retries
+		// Calculate backoff delay for retries, unless the host is already known to be unreachable
 		if attempt > 0 {
+			if err := hosts.check(host); err != nil {
+				return retries, fmt.Errorf("fetch from %s skipped: %w", remoteCfg.Name, err)
+			}
+
 			delay := calculateBackoff(attempt)
 			if opts.Debug {
-				debugPrintf("Retry %d for %s after %v", attempt, repoPath, delay)
+				debugPrintf("Retry %d for %s (%s) after %v", attempt, repoPath, remoteCfg.Name, delay)
 			}
 
 			select {
 			case <-time.After(delay):
 			case <-ctx.Done():
-				result.Error = ctx.Err()
-
-				return result
+				return retries, ctx.Err()
 			}
 		}
 
-		// Perform fetch with timeout
-		fetchErr := performFetch(ctx, repo, remoteURL, opts)
+		// Perform fetch with timeout, within the host's concurrency limit
+		release, err := hosts.acquire(ctx, host)
+		if errors.Is(err, ErrHostUnreachable) {
+			return retries, fmt.Errorf("fetch from %s skipped: %w", remoteCfg.Name, err)
+		}
+		if err != nil {
+			return retries, err
+		}
+		fetchErr := performFetch(ctx, repo, remoteCfg, refSpecs, opts)
+		release()
+		if ctx.Err() == nil {
+			hosts.record(host, fetchErr)
+		}
 		if fetchErr == nil {
-			result.Success = true
-
-			return result
+			return retries, nil
 		}
 
 		// Check for "already up-to-date" - this is success, not error
 		if errors.Is(fetchErr, git.NoErrAlreadyUpToDate) {
-			result.Success = true
+			return retries, nil
+		}
 
-			return result
+		// A narrowed fetch of a branch the remote does not have has nothing to fetch
+		if refSpecs != nil && errors.Is(fetchErr, git.NoMatchingRefSpecError{}) {
+			return retries, nil
 		}
 
 		lastErr = fetchErr
@@ -117,17 +251,51 @@ func fetchFromOrigin(ctx context.Context, repoPath string, opts *ExtractOptions)
 		}
 
 		if opts.Debug {
-			debugPrintf("Fetch attempt %d failed for %s: %v", attempt+1, repoPath, fetchErr)
+			debugPrintf("Fetch attempt %d failed for %s (%s): %v", attempt+1, repoPath, remoteCfg.Name, fetchErr)
 		}
 	}
 
-	result.Error = fmt.Errorf("fetch failed after retries: %w", lastErr)
+	return retries, fmt.Errorf("fetch from %s failed after retries: %w", remoteCfg.Name, lastErr)
+}
 
-	return result
+// currentBranchRefSpecs returns a refspec fetching only the branch the checked-out branch
+// tracks on remoteCfg (its configured merge branch, or the branch of the same name), mapped
+// through the remote's fetch refspecs. It returns nil when HEAD is detached.
+func currentBranchRefSpecs(repo *git.Repository, remoteCfg *config.RemoteConfig) []config.RefSpec {
+	// HEAD is read without resolving it, so an unborn branch still has a name
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil || head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return nil
+	}
+
+	src := head.Target()
+	if cfg, err := repo.Config(); err == nil {
+		if branchCfg, exists := cfg.Branches[src.Short()]; exists && branchCfg.Remote == remoteCfg.Name && branchCfg.Merge != "" {
+			src = branchCfg.Merge
+		}
+	}
+
+	dst := plumbing.NewRemoteReferenceName(remoteCfg.Name, src.Short())
+	for _, refSpec := range remoteCfg.Fetch {
+		if refSpec.Match(src) {
+			dst = refSpec.Dst(src)
+
+			break
+		}
+	}
+
+	return []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", src, dst))}
 }
 
-// performFetch executes a single fetch operation with timeout.
-func performFetch(ctx context.Context, repo *git.Repository, remoteURL string, opts *ExtractOptions) error {
+// performFetch executes a single fetch operation with timeout. Nil refSpecs fetch the remote's
+// configured refspecs.
+func performFetch(
+	ctx context.Context,
+	repo *git.Repository,
+	remoteCfg *config.RemoteConfig,
+	refSpecs []config.RefSpec,
+	opts *ExtractOptions,
+) error {
 	fetchCtx := ctx
 	if opts.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -136,10 +304,13 @@ func performFetch(ctx context.Context, repo *git.Repository, remoteURL string, o
 	}
 
 	// Get authentication for HTTPS URLs
-	auth := getAuthForURL(fetchCtx, remoteURL, opts.Debug)
+	auth := getAuthForURL(fetchCtx, remoteCfg.URLs[0], opts.Debug)
 
 	return repo.FetchContext(fetchCtx, &git.FetchOptions{
-		RemoteName: originRemote,
+		RemoteName: remoteCfg.Name,
+		RefSpecs:   refSpecs,
+		Tags:       opts.FetchTags.tagMode(),
+		Prune:      opts.Prune,
 		Auth:       auth,
 	})
 }
@@ -152,29 +323,38 @@ func calculateBackoff(attempt int) time.Duration {
 	return min(delay, maxBackoffDelay)
 }
 
-// fetchBatch performs concurrent fetch operations for multiple repositories.
-// Thread-safety note: All FetchStats modifications occur in the main goroutine.
+// fetchBatch performs concurrent fetch operations for multiple repositories. If onDone is not
+// nil, it is called with the paths of the repositories whose fetch has finished, been skipped or
+// been found unnecessary, as soon as that is known and after their fetch error is recorded.
+// Repositories not fetched because ctx was canceled are not reported.
+// Thread-safety note: All FetchStats modifications and onDone calls occur in the main goroutine.
 // The spawned goroutines only communicate via the results channel, so no mutex is needed.
 func fetchBatch(
 	ctx context.Context,
 	repos map[string]*models.Repository,
 	opts *ExtractOptions,
 	batchResult *models.BatchResult,
+	onDone func(paths []string),
 ) {
+	done := func(paths ...string) {
+		if onDone != nil {
+			onDone(paths)
+		}
+	}
+
 	if batchResult.FetchStats == nil {
 		batchResult.FetchStats = &models.FetchStats{}
 	}
 
 	type fetchResultPair struct {
-		path   string
+		key    string
 		result *FetchResult
 	}
 
-	results := make(chan fetchResultPair, len(repos))
-	semaphore := make(chan struct{}, opts.MaxConcurrency)
-
-	var wg sync.WaitGroup
-
+	// Linked worktrees share the refs and objects of their repository, so each common
+	// git directory is fetched only once and the result applies to all of its worktrees
+	groups := make(map[string][]string)
+	stampDirs := make(map[string]string)
 	for path, repo := range repos {
 		// Skip nil repositories
 		if repo == nil {
@@ -183,14 +363,49 @@ func fetchBatch(
 		// Skip bare repositories - they typically don't have working trees to fetch into
 		if repo.IsBare {
 			batchResult.FetchStats.Skipped++
+			done(path)
 
 			continue
 		}
 
+		key := fetchKey(path, repo)
+		groups[key] = append(groups[key], path)
+		stampDirs[key] = commonGitDir(path, repo)
+	}
+
+	// Repositories fetched recently, by gitree or by git itself, are not fetched again
+	if opts.FetchMaxAge > 0 {
+		now := time.Now()
+		for key, paths := range groups {
+			gitDirs := []string{stampDirs[key]}
+			for _, path := range paths {
+				gitDirs = append(gitDirs, gitDir(path, repos[path]))
+			}
+
+			if lastFetch := lastFetchTime(gitDirs); now.Sub(lastFetch) < opts.FetchMaxAge {
+				if opts.Debug {
+					debugPrintf("Skipping fetch of %s: fetched %v ago", key, now.Sub(lastFetch).Round(time.Second))
+				}
+				batchResult.FetchStats.Fresh++
+				done(paths...)
+				delete(groups, key)
+			}
+		}
+	}
+
+	results := make(chan fetchResultPair, len(groups))
+	semaphore := make(chan struct{}, opts.MaxConcurrency)
+	hosts := newHostLimiter(opts.MaxConcurrencyPerHost, opts.HostFailureLimit)
+
+	var wg sync.WaitGroup
+
+	for key, paths := range groups {
+		sort.Strings(paths)
+
 		wg.Add(1)
 		batchResult.FetchStats.TotalAttempted++
 
-		go func(repoPath string) {
+		go func(key, repoPath string) {
 			defer wg.Done()
 
 			select {
@@ -206,9 +421,9 @@ func fetchBatch(
 				return
 			}
 
-			fetchResult := fetchFromOrigin(ctx, repoPath, opts)
-			results <- fetchResultPair{path: repoPath, result: fetchResult}
-		}(path)
+			fetchResult := fetchRemotes(ctx, repoPath, opts, hosts)
+			results <- fetchResultPair{key: key, result: fetchResult}
+		}(key, paths[0])
 	}
 
 	go func() {
@@ -224,17 +439,97 @@ func fetchBatch(
 			batchResult.FetchStats.TotalAttempted-- // Was counted but skipped
 		case r.result.Success:
 			batchResult.FetchStats.Successful++
+			// Only a fetch of every branch makes the repository fresh for later runs
+			if opts.FetchMaxAge > 0 && !opts.FetchCurrentBranch {
+				writeFetchStamp(stampDirs[r.key], opts)
+			}
 		default:
 			batchResult.FetchStats.Failed++
-			batchResult.FetchStats.FailedRepos = append(batchResult.FetchStats.FailedRepos, r.path)
-
-			// Store fetch error in the repository's GitStatus
-			if repo, exists := repos[r.path]; exists && r.result.Error != nil {
-				if repo.GitStatus == nil {
-					repo.GitStatus = &models.GitStatus{}
+			batchResult.FetchStats.FailedRepos = append(batchResult.FetchStats.FailedRepos, groups[r.key]...)
+
+			// Store fetch error in the GitStatus of every repository sharing the fetch
+			for _, path := range groups[r.key] {
+				if repo, exists := repos[path]; exists && r.result.Error != nil {
+					if repo.GitStatus == nil {
+						repo.GitStatus = &models.GitStatus{}
+					}
+					repo.GitStatus.FetchError = r.result.Error.Error()
 				}
-				repo.GitStatus.FetchError = r.result.Error.Error()
 			}
 		}
+
+		done(groups[r.key]...)
+	}
+
+	batchResult.FetchStats.UnreachableHosts = hosts.unreachableHosts()
+}
+
+// attachFetchError copies the fetch error that fetchBatch recorded on repo into its freshly
+// extracted status, which replaces the one holding it. fetchBatch records the error before it
+// releases the repository for extraction, so no other synchronization is needed.
+func attachFetchError(repo *models.Repository, status *models.GitStatus) {
+	if repo != nil && repo.GitStatus != nil && status.FetchError == "" {
+		status.FetchError = repo.GitStatus.FetchError
+	}
+}
+
+// fetchKey identifies the git directory a fetch updates: the common directory shared by
+// all worktrees of a repository, or the repository path if it is unknown.
+func fetchKey(path string, repo *models.Repository) string {
+	if repo.CommonDir != "" {
+		return repo.CommonDir
+	}
+
+	return path
+}
+
+// gitDir returns the git directory of the working tree at path.
+func gitDir(path string, repo *models.Repository) string {
+	if repo.GitDir != "" {
+		return repo.GitDir
+	}
+
+	return filepath.Join(path, ".git")
+}
+
+// commonGitDir returns the git directory shared by all worktrees of the repository at path.
+func commonGitDir(path string, repo *models.Repository) string {
+	if repo.CommonDir != "" {
+		return repo.CommonDir
+	}
+
+	return gitDir(path, repo)
+}
+
+// lastFetchTime returns when a repository was last fetched: the newest modification time of
+// the FETCH_HEAD and gitree fetch stamp files in gitDirs. It is zero if there are none.
+func lastFetchTime(gitDirs []string) time.Time {
+	var last time.Time
+	for _, dir := range gitDirs {
+		for _, name := range []string{fetchHeadFile, fetchStampFile} {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.ModTime().After(last) {
+				last = info.ModTime()
+			}
+		}
+	}
+
+	return last
+}
+
+// writeFetchStamp records a successful fetch in the git directory dir. go-git does not write
+// FETCH_HEAD, so gitree keeps its own timestamp for FetchMaxAge; it is written only when
+// FetchMaxAge is set, so repositories are left untouched otherwise. Failures only affect how
+// soon the repository is fetched again and are not reported as errors.
+func writeFetchStamp(dir string, opts *ExtractOptions) {
+	stampPath := filepath.Join(dir, fetchStampFile)
+	now := time.Now()
+
+	// Truncating an empty file does not update its modification time everywhere
+	err := os.WriteFile(stampPath, nil, 0o600)
+	if err == nil {
+		err = os.Chtimes(stampPath, now, now)
+	}
+	if err != nil && opts.Debug {
+		debugPrintf("Failed to record fetch time in %s: %v", dir, err)
 	}
 }
@@ -3,14 +3,18 @@ package gitstatus
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/andreygrechin/gitree/internal/models"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,8 +72,8 @@ func createTestRepoWithLocalRemote(t *testing.T) string {
 	return repoPath
 }
 
-// T_F001: Test fetchFromOrigin with valid origin remote.
-func TestFetchFromOrigin_Success(t *testing.T) {
+// T_F001: Test fetchRemotes with valid origin remote.
+func TestFetchRemotes_Success(t *testing.T) {
 	repoPath := createTestRepoWithLocalRemote(t)
 
 	ctx := context.Background()
@@ -78,15 +82,15 @@ func TestFetchFromOrigin_Success(t *testing.T) {
 		FetchRetries: 3,
 	}
 
-	result := fetchFromOrigin(ctx, repoPath, opts)
+	result := fetchRemotes(ctx, repoPath, opts, nil)
 
 	assert.True(t, result.Success, "fetch should succeed or be already up-to-date")
 	assert.False(t, result.Skipped)
 	assert.NoError(t, result.Error)
 }
 
-// T_F002: Test fetchFromOrigin with no origin remote (should skip, not error).
-func TestFetchFromOrigin_NoOrigin(t *testing.T) {
+// T_F002: Test fetchRemotes with no origin remote (should skip, not error).
+func TestFetchRemotes_NoOrigin(t *testing.T) {
 	// Create repo without origin
 	repoPath := createTestRepoWithState(t, "basic")
 
@@ -96,14 +100,14 @@ func TestFetchFromOrigin_NoOrigin(t *testing.T) {
 		FetchRetries: 3,
 	}
 
-	result := fetchFromOrigin(ctx, repoPath, opts)
+	result := fetchRemotes(ctx, repoPath, opts, nil)
 
 	assert.True(t, result.Skipped)
 	assert.False(t, result.Success)
 	assert.NoError(t, result.Error)
 }
 
-// T_F003: Test fetchFromOrigin already up-to-date.
+// T_F003: Test fetchRemotes already up-to-date.
 // Removed
 
 // T_F004: Test calculateBackoff returns correct delays.
@@ -130,7 +134,7 @@ func TestCalculateBackoff(t *testing.T) {
 }
 
 // T_F005: Test context timeout is respected.
-func TestFetchFromOrigin_RespectsTimeout(t *testing.T) {
+func TestFetchRemotes_RespectsTimeout(t *testing.T) {
 	repoPath := createTestRepoWithLocalRemote(t)
 
 	// Create context with very short timeout
@@ -145,7 +149,7 @@ func TestFetchFromOrigin_RespectsTimeout(t *testing.T) {
 		FetchRetries: 1,
 	}
 
-	result := fetchFromOrigin(ctx, repoPath, opts)
+	result := fetchRemotes(ctx, repoPath, opts, nil)
 
 	// Should return context error
 	require.Error(t, result.Error)
@@ -175,7 +179,7 @@ func TestFetchBatch_ConcurrentProcessing(t *testing.T) {
 		Statuses: make(map[string]*models.GitStatus),
 	}
 
-	fetchBatch(ctx, repos, opts, batchResult)
+	fetchBatch(ctx, repos, opts, batchResult, nil)
 
 	assert.NotNil(t, batchResult.FetchStats)
 	assert.Equal(t, 3, batchResult.FetchStats.TotalAttempted)
@@ -204,7 +208,7 @@ func TestFetchBatch_SkipsBareRepos(t *testing.T) {
 		Statuses: make(map[string]*models.GitStatus),
 	}
 
-	fetchBatch(ctx, repos, opts, batchResult)
+	fetchBatch(ctx, repos, opts, batchResult, nil)
 
 	assert.NotNil(t, batchResult.FetchStats)
 	// Bare repo should be skipped, regular should be attempted
@@ -232,7 +236,7 @@ func TestFetchBatch_NoOriginSkipped(t *testing.T) {
 		Statuses: make(map[string]*models.GitStatus),
 	}
 
-	fetchBatch(ctx, repos, opts, batchResult)
+	fetchBatch(ctx, repos, opts, batchResult, nil)
 
 	assert.NotNil(t, batchResult.FetchStats)
 	// Should be counted as attempted then decremented when skipped
@@ -241,15 +245,43 @@ func TestFetchBatch_NoOriginSkipped(t *testing.T) {
 	assert.Equal(t, 0, batchResult.FetchStats.Failed)
 }
 
-// T_F009: Test fetchFromOrigin with non-existent path.
-func TestFetchFromOrigin_NonExistentPath(t *testing.T) {
+// Test fetchBatch fetches repositories sharing a common git directory only once.
+func TestFetchBatch_DeduplicatesWorktrees(t *testing.T) {
+	repoPath := createTestRepoWithLocalRemote(t)
+	worktreePath := addTestWorktree(t, repoPath, "feature")
+	otherPath := createTestRepoWithLocalRemote(t)
+
+	commonDir := filepath.Join(repoPath, ".git")
+	repos := map[string]*models.Repository{
+		repoPath:     {Path: repoPath, Name: "main", CommonDir: commonDir},
+		worktreePath: {Path: worktreePath, Name: "feature", CommonDir: commonDir, IsWorktree: true, WorktreeOf: repoPath},
+		otherPath:    {Path: otherPath, Name: "other"},
+	}
+
+	opts := &ExtractOptions{
+		Timeout:        10 * time.Second,
+		MaxConcurrency: 2,
+		FetchRetries:   1,
+	}
+	batchResult := &models.BatchResult{
+		Statuses: make(map[string]*models.GitStatus),
+	}
+
+	fetchBatch(context.Background(), repos, opts, batchResult, nil)
+
+	assert.Equal(t, 2, batchResult.FetchStats.TotalAttempted)
+	assert.Equal(t, 2, batchResult.FetchStats.Successful)
+}
+
+// T_F009: Test fetchRemotes with non-existent path.
+func TestFetchRemotes_NonExistentPath(t *testing.T) {
 	ctx := context.Background()
 	opts := &ExtractOptions{
 		Timeout:      10 * time.Second,
 		FetchRetries: 1,
 	}
 
-	result := fetchFromOrigin(ctx, "/nonexistent/path", opts)
+	result := fetchRemotes(ctx, "/nonexistent/path", opts, nil)
 
 	assert.False(t, result.Success)
 	assert.False(t, result.Skipped)
@@ -309,4 +341,415 @@ func TestDefaultOptions_FetchDefaults(t *testing.T) {
 
 	assert.True(t, opts.Fetch, "Fetch should be enabled by default")
 	assert.Equal(t, defaultFetchRetries, opts.FetchRetries, "FetchRetries should match default")
+	assert.Equal(t, defaultMaxPerHost, opts.MaxConcurrencyPerHost, "MaxConcurrencyPerHost should match default")
+	assert.Equal(t, defaultHostFailures, opts.HostFailureLimit, "HostFailureLimit should match default")
+}
+
+// createTestRemote creates a bare repository whose master branch has one commit and whose
+// feature branch adds a second commit tagged v1.0. It returns the path of the bare repository.
+func createTestRemote(t *testing.T) string {
+	t.Helper()
+
+	remotePath := t.TempDir()
+	_, err := git.PlainInit(remotePath, true)
+	require.NoError(t, err)
+
+	sourcePath := t.TempDir()
+	source, err := git.PlainInit(sourcePath, false)
+	require.NoError(t, err)
+	_, err = source.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	require.NoError(t, err)
+
+	worktree, err := source.Worktree()
+	require.NoError(t, err)
+	sig := &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()}
+	commit := func(content string) plumbing.Hash {
+		require.NoError(t, os.WriteFile(filepath.Join(sourcePath, "test.txt"), []byte(content), 0o600))
+		_, err := worktree.Add("test.txt")
+		require.NoError(t, err)
+		hash, err := worktree.Commit(content, &git.CommitOptions{Author: sig})
+		require.NoError(t, err)
+
+		return hash
+	}
+
+	commit("initial content")
+	require.NoError(t, worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}))
+	_, err = source.CreateTag("v1.0", commit("feature content"), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, source.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	}))
+
+	return remotePath
+}
+
+// createTestRepoWithRemotes creates an empty repository with a remote for each name and path.
+func createTestRepoWithRemotes(t *testing.T, remotes map[string]string) (string, *git.Repository) {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	repo, err := git.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	for name, url := range remotes {
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: name, URLs: []string{url}})
+		require.NoError(t, err)
+	}
+
+	return repoPath, repo
+}
+
+// refNames returns the names of the references of repo with the given prefix.
+func refNames(t *testing.T, repo *git.Repository, prefix string) []string {
+	t.Helper()
+
+	refs, err := repo.References()
+	require.NoError(t, err)
+
+	var names []string
+	require.NoError(t, refs.ForEach(func(ref *plumbing.Reference) error {
+		if strings.HasPrefix(ref.Name().String(), prefix) {
+			names = append(names, ref.Name().String())
+		}
+
+		return nil
+	}))
+	sort.Strings(names)
+
+	return names
+}
+
+// Test fetchRemotes fetches origin by default, every remote, or the named remotes a repository has.
+func TestFetchRemotes_SelectsRemotes(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ExtractOptions
+		fetched []string
+	}{
+		{"origin by default", ExtractOptions{}, []string{"origin"}},
+		{"all remotes", ExtractOptions{FetchAllRemotes: true}, []string{"origin", "upstream"}},
+		{"named remotes", ExtractOptions{FetchRemotes: []string{"upstream", "missing", "upstream"}}, []string{"upstream"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath, repo := createTestRepoWithRemotes(t, map[string]string{
+				"origin":   createTestRemote(t),
+				"upstream": createTestRemote(t),
+			})
+
+			opts := tt.opts
+			opts.Timeout = 10 * time.Second
+			opts.FetchRetries = 1
+			result := fetchRemotes(context.Background(), repoPath, &opts, nil)
+			require.NoError(t, result.Error)
+			assert.True(t, result.Success)
+
+			var expected []string
+			for _, remote := range tt.fetched {
+				expected = append(expected, "refs/remotes/"+remote+"/feature", "refs/remotes/"+remote+"/master")
+			}
+			assert.Equal(t, expected, refNames(t, repo, "refs/remotes/"))
+		})
+	}
+}
+
+// Test fetchRemotes skips a repository that has none of the named remotes.
+func TestFetchRemotes_NoNamedRemoteSkipped(t *testing.T) {
+	repoPath, _ := createTestRepoWithRemotes(t, map[string]string{"origin": createTestRemote(t)})
+
+	opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchRemotes: []string{"upstream"}}
+	result := fetchRemotes(context.Background(), repoPath, opts, nil)
+
+	assert.True(t, result.Skipped)
+	assert.NoError(t, result.Error)
+}
+
+// Test fetchRemotes fetches the remaining remotes when one fails and names the failed one.
+func TestFetchRemotes_ReportsFailedRemote(t *testing.T) {
+	repoPath, repo := createTestRepoWithRemotes(t, map[string]string{
+		"broken": filepath.Join(t.TempDir(), "missing"),
+		"origin": createTestRemote(t),
+	})
+
+	opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchAllRemotes: true}
+	result := fetchRemotes(context.Background(), repoPath, opts, nil)
+
+	require.Error(t, result.Error)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error.Error(), "fetch from broken failed")
+	assert.Equal(t, []string{"refs/remotes/origin/feature", "refs/remotes/origin/master"}, refNames(t, repo, "refs/remotes/"))
+}
+
+// Test ExtractBatch reports the error of a failed fetch in the repository's extracted status.
+func TestExtractBatch_ReportsFetchError(t *testing.T) {
+	repoPath, _ := createTestRepoWithRemotes(t, map[string]string{"origin": filepath.Join(t.TempDir(), "missing")})
+	repos := map[string]*models.Repository{repoPath: {Path: repoPath, Name: "broken-remote"}}
+
+	opts := &ExtractOptions{Timeout: 10 * time.Second, MaxConcurrency: 2, Fetch: true, FetchRetries: 1}
+	batchResult := ExtractBatch(context.Background(), repos, opts)
+
+	require.Contains(t, batchResult.Statuses, repoPath)
+	assert.Contains(t, batchResult.Statuses[repoPath].FetchError, "fetch from origin failed")
+	assert.Equal(t, 1, batchResult.FetchStats.Failed)
+}
+
+// Test fetchRemotes removes remote-tracking references of deleted branches only when pruning.
+func TestFetchRemotes_Prune(t *testing.T) {
+	remotePath := createTestRemote(t)
+	repoPath, repo := createTestRepoWithRemotes(t, map[string]string{"origin": remotePath})
+
+	opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1}
+	require.NoError(t, fetchRemotes(context.Background(), repoPath, opts, nil).Error)
+
+	remote, err := git.PlainOpen(remotePath)
+	require.NoError(t, err)
+	require.NoError(t, remote.Storer.RemoveReference(plumbing.NewBranchReferenceName("feature")))
+
+	require.NoError(t, fetchRemotes(context.Background(), repoPath, opts, nil).Error)
+	assert.Equal(t, []string{"refs/remotes/origin/feature", "refs/remotes/origin/master"}, refNames(t, repo, "refs/remotes/"))
+
+	opts.Prune = true
+	require.NoError(t, fetchRemotes(context.Background(), repoPath, opts, nil).Error)
+	assert.Equal(t, []string{"refs/remotes/origin/master"}, refNames(t, repo, "refs/remotes/"))
+}
+
+// Test fetchRemotes applies the tag policy.
+func TestFetchRemotes_TagPolicy(t *testing.T) {
+	tests := []struct {
+		policy FetchTagsPolicy
+		tags   []string
+	}{
+		{FetchTagsAll, []string{"refs/tags/v1.0"}},
+		{FetchTagsNone, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			repoPath, repo := createTestRepoWithRemotes(t, map[string]string{"origin": createTestRemote(t)})
+
+			opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchTags: tt.policy}
+			require.NoError(t, fetchRemotes(context.Background(), repoPath, opts, nil).Error)
+
+			assert.Equal(t, tt.tags, refNames(t, repo, "refs/tags/"))
+		})
+	}
+}
+
+// Test fetchRemotes fetches only the branch the checked-out branch tracks when narrowed.
+func TestFetchRemotes_CurrentBranch(t *testing.T) {
+	t.Run("branch of the same name", func(t *testing.T) {
+		repoPath, repo := createTestRepoWithRemotes(t, map[string]string{"origin": createTestRemote(t)})
+
+		opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchCurrentBranch: true}
+		result := fetchRemotes(context.Background(), repoPath, opts, nil)
+		require.NoError(t, result.Error)
+		assert.True(t, result.Success)
+
+		assert.Equal(t, []string{"refs/remotes/origin/master"}, refNames(t, repo, "refs/remotes/"))
+	})
+
+	t.Run("configured upstream branch", func(t *testing.T) {
+		repoPath, repo := createTestRepoWithRemotes(t, map[string]string{"origin": createTestRemote(t)})
+		require.NoError(t, repo.CreateBranch(&config.Branch{
+			Name:   "master",
+			Remote: "origin",
+			Merge:  plumbing.NewBranchReferenceName("feature"),
+		}))
+
+		opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchCurrentBranch: true}
+		require.NoError(t, fetchRemotes(context.Background(), repoPath, opts, nil).Error)
+
+		assert.Equal(t, []string{"refs/remotes/origin/feature"}, refNames(t, repo, "refs/remotes/"))
+	})
+
+	t.Run("branch missing on the remote", func(t *testing.T) {
+		repoPath, repo := createTestRepoWithRemotes(t, map[string]string{"origin": createTestRemote(t)})
+		require.NoError(t, repo.Storer.SetReference(
+			plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("local-only"))))
+
+		opts := &ExtractOptions{Timeout: 10 * time.Second, FetchRetries: 1, FetchCurrentBranch: true}
+		result := fetchRemotes(context.Background(), repoPath, opts, nil)
+		require.NoError(t, result.Error)
+		assert.True(t, result.Success)
+
+		assert.Empty(t, refNames(t, repo, "refs/remotes/"))
+	})
+}
+
+// Test fetchBatch skips repositories fetched within FetchMaxAge and records successful fetches.
+func TestFetchBatch_SkipsFreshRepos(t *testing.T) {
+	freshPath := createTestRepoWithLocalRemote(t)
+	stalePath := createTestRepoWithLocalRemote(t)
+	neverPath := createTestRepoWithLocalRemote(t)
+	stampedPath := createTestRepoWithLocalRemote(t)
+
+	// FETCH_HEAD written by git fetch counts as well as gitree's own stamp
+	touch := func(path string, age time.Duration) {
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+		when := time.Now().Add(-age)
+		require.NoError(t, os.Chtimes(path, when, when))
+	}
+	touch(filepath.Join(freshPath, ".git", fetchHeadFile), 10*time.Minute)
+	touch(filepath.Join(stalePath, ".git", fetchHeadFile), 2*time.Hour)
+	touch(filepath.Join(stampedPath, ".git", fetchStampFile), time.Minute)
+
+	repos := map[string]*models.Repository{
+		freshPath:   {Path: freshPath, Name: "fresh"},
+		stalePath:   {Path: stalePath, Name: "stale"},
+		neverPath:   {Path: neverPath, Name: "never"},
+		stampedPath: {Path: stampedPath, Name: "stamped"},
+	}
+	opts := &ExtractOptions{
+		Timeout:        10 * time.Second,
+		MaxConcurrency: 2,
+		FetchRetries:   1,
+		FetchMaxAge:    time.Hour,
+	}
+	batchResult := &models.BatchResult{Statuses: make(map[string]*models.GitStatus)}
+
+	fetchBatch(context.Background(), repos, opts, batchResult, nil)
+
+	assert.Equal(t, 2, batchResult.FetchStats.Fresh)
+	assert.Equal(t, 2, batchResult.FetchStats.TotalAttempted)
+	assert.Equal(t, 2, batchResult.FetchStats.Successful)
+
+	// The fetched repositories are now fresh
+	for _, path := range []string{stalePath, neverPath} {
+		assert.WithinDuration(t, time.Now(), lastFetchTime([]string{filepath.Join(path, ".git")}), time.Minute, path)
+	}
+	assert.NoFileExists(t, filepath.Join(freshPath, ".git", fetchStampFile))
+
+	batchResult = &models.BatchResult{Statuses: make(map[string]*models.GitStatus)}
+	fetchBatch(context.Background(), repos, opts, batchResult, nil)
+	assert.Equal(t, 4, batchResult.FetchStats.Fresh)
+	assert.Equal(t, 0, batchResult.FetchStats.TotalAttempted)
+}
+
+// Test fetchBatch stops fetching from a host that keeps refusing connections.
+func TestFetchBatch_SkipsUnreachableHost(t *testing.T) {
+	url := closedPortURL(t)
+	repos := make(map[string]*models.Repository)
+	for i := range 4 {
+		repoPath, _ := createTestRepoWithRemotes(t, map[string]string{"origin": url})
+		repos[repoPath] = &models.Repository{Path: repoPath, Name: fmt.Sprintf("repo%d", i)}
+	}
+	localPath := createTestRepoWithLocalRemote(t)
+	repos[localPath] = &models.Repository{Path: localPath, Name: "local"}
+
+	opts := &ExtractOptions{
+		Timeout:          10 * time.Second,
+		MaxConcurrency:   1,
+		FetchRetries:     3,
+		HostFailureLimit: 2,
+	}
+	batchResult := &models.BatchResult{Statuses: make(map[string]*models.GitStatus)}
+
+	start := time.Now()
+	fetchBatch(context.Background(), repos, opts, batchResult, nil)
+
+	// Only the first repository is retried; the others are skipped without backoff
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.Equal(t, 4, batchResult.FetchStats.Failed)
+	assert.Equal(t, 1, batchResult.FetchStats.Successful)
+	assert.Equal(t, []string{"127.0.0.1"}, batchResult.FetchStats.UnreachableHosts)
+
+	unreachable := 0
+	for path, repo := range repos {
+		if path == localPath {
+			assert.Nil(t, repo.GitStatus)
+
+			continue
+		}
+		require.NotNil(t, repo.GitStatus, path)
+		if strings.Contains(repo.GitStatus.FetchError, "fetch from origin skipped: host unreachable") {
+			unreachable++
+		}
+	}
+	// The first repository stops retrying as soon as the host is marked unreachable
+	assert.Equal(t, 4, unreachable)
+}
+
+// Test fetchBatch records a fetch only when FetchMaxAge is set and every branch was fetched.
+func TestFetchBatch_FetchStamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ExtractOptions
+		stamped bool
+	}{
+		{"max age not set", ExtractOptions{}, false},
+		{"narrowed to the current branch", ExtractOptions{FetchMaxAge: time.Hour, FetchCurrentBranch: true}, false},
+		{"every branch", ExtractOptions{FetchMaxAge: time.Hour}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoPath := createTestRepoWithLocalRemote(t)
+			repos := map[string]*models.Repository{repoPath: {Path: repoPath, Name: "repo"}}
+
+			opts := tt.opts
+			opts.Timeout = 10 * time.Second
+			opts.MaxConcurrency = 1
+			opts.FetchRetries = 1
+			batchResult := &models.BatchResult{Statuses: make(map[string]*models.GitStatus)}
+
+			fetchBatch(context.Background(), repos, &opts, batchResult, nil)
+			require.Equal(t, 1, batchResult.FetchStats.Successful)
+
+			stampPath := filepath.Join(repoPath, ".git", fetchStampFile)
+			if tt.stamped {
+				assert.FileExists(t, stampPath)
+			} else {
+				assert.NoFileExists(t, stampPath)
+			}
+		})
+	}
+}
+
+// Test ExtractBatch reports a repository through OnResult as soon as its own fetch is done,
+// while the fetch of another repository is still waiting for its remote.
+func TestExtractBatch_StreamsBeforeSlowFetches(t *testing.T) {
+	// A remote that accepts connections but never answers
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+
+	fastPath := createTestRepoWithLocalRemote(t)
+	slowPath, _ := createTestRepoWithRemotes(t, map[string]string{"origin": "http://" + listener.Addr().String() + "/repo.git"})
+	repos := map[string]*models.Repository{
+		fastPath: {Path: fastPath, Name: "fast"},
+		slowPath: {Path: slowPath, Name: "slow"},
+	}
+
+	var order []string
+	var fastAt time.Duration
+	start := time.Now()
+	opts := &ExtractOptions{
+		Timeout:        2 * time.Second,
+		MaxConcurrency: 2,
+		Fetch:          true,
+		FetchRetries:   1,
+		OnResult: func(path string, _ *models.GitStatus) {
+			if path == fastPath {
+				fastAt = time.Since(start)
+			}
+			order = append(order, path)
+		},
+	}
+	batchResult := ExtractBatch(context.Background(), repos, opts)
+
+	assert.Equal(t, []string{fastPath, slowPath}, order)
+	assert.Less(t, fastAt, time.Second, "the fast repository is not held back by the slow fetch")
+	assert.NotEmpty(t, batchResult.Statuses[slowPath].FetchError)
 }
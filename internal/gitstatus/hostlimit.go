@@ -0,0 +1,155 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+)
+
+// ErrHostUnreachable is returned for fetches skipped because their remote host failed to
+// connect too many times in a row.
+var ErrHostUnreachable = errors.New("host unreachable")
+
+// hostLimiter limits the number of concurrent fetches from each remote host and acts as a
+// circuit breaker: after failureLimit consecutive connection failures to a host, the remaining
+// fetches from that host are skipped instead of retried. Remotes without a host (local paths)
+// are never limited. A nil hostLimiter limits nothing.
+type hostLimiter struct {
+	maxPerHost   int // Concurrent fetches per host; 0 is unlimited
+	failureLimit int // Consecutive connection failures after which a host is skipped; 0 never skips
+
+	mu       sync.Mutex
+	slots    map[string]chan struct{} // Fetch slots per host
+	failures map[string]int           // Consecutive connection failures per host
+}
+
+// newHostLimiter returns a hostLimiter with the given per-host concurrency and failure limits.
+func newHostLimiter(maxPerHost, failureLimit int) *hostLimiter {
+	return &hostLimiter{
+		maxPerHost:   maxPerHost,
+		failureLimit: failureLimit,
+		slots:        make(map[string]chan struct{}),
+		failures:     make(map[string]int),
+	}
+}
+
+// acquire waits for a fetch slot on host and returns the function releasing it. It fails with
+// ErrHostUnreachable if the host is, or becomes while waiting, unreachable, or with the
+// context error if ctx is done first.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l == nil || host == "" {
+		return func() {}, nil
+	}
+	if err := l.check(host); err != nil {
+		return nil, err
+	}
+
+	slot := l.slot(host)
+	if slot == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	release := func() { <-slot }
+	if err := l.check(host); err != nil {
+		release()
+
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// slot returns the semaphore of host, or nil if concurrency is unlimited.
+func (l *hostLimiter) slot(host string) chan struct{} {
+	if l.maxPerHost <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slot, exists := l.slots[host]
+	if !exists {
+		slot = make(chan struct{}, l.maxPerHost)
+		l.slots[host] = slot
+	}
+
+	return slot
+}
+
+// check returns an error wrapping ErrHostUnreachable if host has failed to connect
+// failureLimit times in a row.
+func (l *hostLimiter) check(host string) error {
+	if l == nil || host == "" || l.failureLimit <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if failures := l.failures[host]; failures >= l.failureLimit {
+		return fmt.Errorf("%w: %s failed to connect %d times in a row", ErrHostUnreachable, host, failures)
+	}
+
+	return nil
+}
+
+// record updates the consecutive connection failures of host with the outcome of a fetch.
+// Any other outcome, including errors such as rejected credentials, shows the host is reachable.
+func (l *hostLimiter) record(host string, err error) {
+	if l == nil || host == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if isConnectionError(err) {
+		l.failures[host]++
+	} else {
+		l.failures[host] = 0
+	}
+}
+
+// unreachableHosts returns the hosts whose fetches are being skipped, sorted by name.
+func (l *hostLimiter) unreachableHosts() []string {
+	if l == nil || l.failureLimit <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var hosts []string
+	for host, failures := range l.failures {
+		if failures >= l.failureLimit {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// isConnectionError reports whether a fetch failed to reach its host: a refused connection,
+// a dial timeout or a failed DNS lookup. A fetch that times out or breaks after connecting,
+// such as a slow transfer of a large repository, did reach the host.
+func isConnectionError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
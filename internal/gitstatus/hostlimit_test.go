@@ -0,0 +1,151 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test hostLimiter allows at most maxPerHost concurrent fetches per host.
+func TestHostLimiter_LimitsConcurrencyPerHost(t *testing.T) {
+	limiter := newHostLimiter(2, 0)
+
+	var active, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := limiter.acquire(context.Background(), "git.example.com")
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer release()
+
+			current := active.Add(1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			active.Add(-1)
+		}()
+	}
+
+	// Other hosts and local remotes are not held up by a busy host
+	release, err := limiter.acquire(context.Background(), "github.com")
+	require.NoError(t, err)
+	release()
+	release, err = limiter.acquire(context.Background(), "")
+	require.NoError(t, err)
+	release()
+
+	wg.Wait()
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+// Test hostLimiter skips a host after consecutive connection failures only.
+func TestHostLimiter_CircuitBreaker(t *testing.T) {
+	connErr := fmt.Errorf("dial: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+	limiter := newHostLimiter(0, 3)
+
+	limiter.record("git.example.com", connErr)
+	limiter.record("git.example.com", connErr)
+	limiter.record("git.example.com", errors.New("authentication required")) // The host answered
+	limiter.record("git.example.com", connErr)
+	limiter.record("git.example.com", connErr)
+	require.NoError(t, limiter.check("git.example.com"))
+
+	limiter.record("git.example.com", connErr)
+	_, err := limiter.acquire(context.Background(), "git.example.com")
+	require.ErrorIs(t, err, ErrHostUnreachable)
+	assert.Contains(t, err.Error(), "git.example.com failed to connect 3 times in a row")
+
+	// Local remotes and other hosts are unaffected
+	limiter.record("", connErr)
+	limiter.record("", connErr)
+	limiter.record("", connErr)
+	require.NoError(t, limiter.check(""))
+	require.NoError(t, limiter.check("github.com"))
+	assert.Equal(t, []string{"git.example.com"}, limiter.unreachableHosts())
+
+	// Without a failure limit hosts are never skipped
+	unlimited := newHostLimiter(0, 0)
+	for range 10 {
+		unlimited.record("git.example.com", connErr)
+	}
+	require.NoError(t, unlimited.check("git.example.com"))
+	assert.Empty(t, unlimited.unreachableHosts())
+}
+
+// Test only failures to dial or resolve a host count as connection failures, not fetches that
+// time out or break after connecting.
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{
+			"dial timeout behind an HTTP request",
+			&url.Error{Op: "Get", URL: "https://git.example.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}},
+			true,
+		},
+		{"DNS lookup", fmt.Errorf("fetch: %w", &net.DNSError{Err: "no such host", Name: "git.example.com"}), true},
+		{"whole-fetch timeout", fmt.Errorf("fetch: %w", context.DeadlineExceeded), false},
+		{"read timeout after connecting", &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}, false},
+		{"rejected credentials", errors.New("authentication required"), false},
+		{"success", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConnectionError(tt.err))
+		})
+	}
+
+	// Slow fetches from a reachable host never mark it unreachable
+	limiter := newHostLimiter(0, 2)
+	for range 5 {
+		limiter.record("git.example.com", context.DeadlineExceeded)
+	}
+	require.NoError(t, limiter.check("git.example.com"))
+}
+
+// Test a nil hostLimiter limits nothing.
+func TestHostLimiter_Nil(t *testing.T) {
+	var limiter *hostLimiter
+
+	release, err := limiter.acquire(context.Background(), "git.example.com")
+	require.NoError(t, err)
+	release()
+	limiter.record("git.example.com", context.DeadlineExceeded)
+	require.NoError(t, limiter.check("git.example.com"))
+	assert.Empty(t, limiter.unreachableHosts())
+}
+
+// closedPortURL returns an http URL on the loopback interface on which connections are refused.
+func closedPortURL(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	return "http://" + addr + "/repo.git"
+}
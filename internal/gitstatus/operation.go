@@ -0,0 +1,93 @@
+package gitstatus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// Labels of in-progress operations, in the style of the git shell prompt.
+const (
+	operationRebasing      = "REBASING"
+	operationApplying      = "AM"
+	operationMerging       = "MERGING"
+	operationCherryPicking = "CHERRY-PICKING"
+	operationReverting     = "REVERTING"
+	operationBisecting     = "BISECTING"
+)
+
+// extractOperation returns the in-progress merge, rebase, cherry-pick, revert, am or bisect
+// operation recorded in the repository's git directory, such as "REBASING 3/7" or "MERGING".
+// An empty string means no operation is in progress or the git directory is not accessible.
+func extractOperation(repo *git.Repository) string {
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return ""
+	}
+
+	return detectOperation(storage.Filesystem())
+}
+
+// detectOperation inspects the state files git leaves in gitDir while an operation is
+// stopped. A rebase takes precedence, since it can itself stop on a cherry-pick or merge.
+func detectOperation(gitDir billy.Filesystem) string {
+	if pathExists(gitDir, "rebase-merge") {
+		return withProgress(operationRebasing, gitDir, "rebase-merge/msgnum", "rebase-merge/end")
+	}
+
+	if pathExists(gitDir, "rebase-apply") {
+		label := operationRebasing
+		if pathExists(gitDir, "rebase-apply/applying") {
+			label = operationApplying
+		}
+
+		return withProgress(label, gitDir, "rebase-apply/next", "rebase-apply/last")
+	}
+
+	for _, marker := range []struct{ file, label string }{
+		{"MERGE_HEAD", operationMerging},
+		{"CHERRY_PICK_HEAD", operationCherryPicking},
+		{"REVERT_HEAD", operationReverting},
+		{"BISECT_LOG", operationBisecting},
+	} {
+		if pathExists(gitDir, marker.file) {
+			return marker.label
+		}
+	}
+
+	return ""
+}
+
+// withProgress appends "step/total" read from the given state files to label.
+// The label is returned alone if either file is missing or malformed.
+func withProgress(label string, gitDir billy.Filesystem, stepFile, totalFile string) string {
+	step, stepErr := readCounter(gitDir, stepFile)
+	total, totalErr := readCounter(gitDir, totalFile)
+	if stepErr != nil || totalErr != nil || total == 0 {
+		return label
+	}
+
+	return fmt.Sprintf("%s %d/%d", label, step, total)
+}
+
+// readCounter reads a file holding a single decimal number.
+func readCounter(fs billy.Filesystem, name string) (int, error) {
+	data, err := util.ReadFile(fs, name)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// pathExists reports whether name exists in fs.
+func pathExists(fs billy.Filesystem, name string) bool {
+	_, err := fs.Stat(name)
+
+	return err == nil
+}
@@ -0,0 +1,79 @@
+package gitstatus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test detectOperation recognizes the state files of each in-progress operation.
+func TestDetectOperation(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{name: "none", want: ""},
+		{
+			name:  "interactive rebase with progress",
+			files: map[string]string{"rebase-merge/msgnum": "3\n", "rebase-merge/end": "7\n", "rebase-merge/interactive": ""},
+			want:  "REBASING 3/7",
+		},
+		{
+			name:  "rebase without readable progress",
+			files: map[string]string{"rebase-merge/head-name": "refs/heads/feature\n"},
+			want:  "REBASING",
+		},
+		{
+			name:  "apply-based rebase",
+			files: map[string]string{"rebase-apply/next": "2\n", "rebase-apply/last": "4\n", "rebase-apply/rebasing": ""},
+			want:  "REBASING 2/4",
+		},
+		{
+			name:  "am session",
+			files: map[string]string{"rebase-apply/next": "1\n", "rebase-apply/last": "5\n", "rebase-apply/applying": ""},
+			want:  "AM 1/5",
+		},
+		{name: "merge", files: map[string]string{"MERGE_HEAD": "abc\n"}, want: "MERGING"},
+		{name: "cherry-pick", files: map[string]string{"CHERRY_PICK_HEAD": "abc\n"}, want: "CHERRY-PICKING"},
+		{name: "revert", files: map[string]string{"REVERT_HEAD": "abc\n"}, want: "REVERTING"},
+		{name: "bisect", files: map[string]string{"BISECT_LOG": "git bisect start\n"}, want: "BISECTING"},
+		{
+			name:  "rebase stopped on a cherry-pick",
+			files: map[string]string{"rebase-merge/msgnum": "1\n", "rebase-merge/end": "2\n", "CHERRY_PICK_HEAD": "abc\n"},
+			want:  "REBASING 1/2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := memfs.New()
+			for name, content := range tt.files {
+				require.NoError(t, util.WriteFile(fs, name, []byte(content), 0o600))
+			}
+
+			assert.Equal(t, tt.want, detectOperation(fs))
+		})
+	}
+}
+
+// Test Extract() reports an in-progress merge.
+func TestExtract_InProgressMerge(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-remote")
+	err := os.WriteFile(filepath.Join(repoPath, ".git", "MERGE_HEAD"), []byte("0000000000000000000000000000000000000000\n"), 0o600)
+	require.NoError(t, err)
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, "MERGING", status.Operation)
+	assert.False(t, status.IsStandardStatus())
+}
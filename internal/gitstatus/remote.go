@@ -0,0 +1,62 @@
+package gitstatus
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// primaryRemote returns the remote used for display: origin if present, otherwise the
+// alphabetically first configured remote. Returns nil if no remotes are configured.
+func primaryRemote(remotes []*git.Remote) *git.Remote {
+	if len(remotes) == 0 {
+		return nil
+	}
+
+	sorted := make([]*git.Remote, len(remotes))
+	copy(sorted, remotes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Config().Name < sorted[j].Config().Name
+	})
+
+	for _, remote := range sorted {
+		if remote.Config().Name == originRemote {
+			return remote
+		}
+	}
+
+	return sorted[0]
+}
+
+// remoteHost extracts the host name from a Git remote URL.
+// It understands URLs with a scheme (https://, ssh://, git://) and scp-like
+// SSH syntax (git@host:path). Local paths and file:// URLs return an empty string.
+func remoteHost(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	if strings.Contains(rawURL, "://") {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Scheme == "file" {
+			return ""
+		}
+
+		return strings.ToLower(parsed.Hostname())
+	}
+
+	// scp-like syntax: [user@]host:path, where host contains no slash before the colon
+	colon := strings.Index(rawURL, ":")
+	if colon <= 0 || strings.Contains(rawURL[:colon], "/") {
+		return ""
+	}
+
+	host := rawURL[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+
+	return strings.ToLower(host)
+}
@@ -0,0 +1,30 @@
+package gitstatus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test remoteHost extracts the host from the supported remote URL forms.
+func TestRemoteHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/user/repo.git", "github.com"},
+		{"https://user@GitLab.example.com:8443/group/repo.git", "gitlab.example.com"},
+		{"ssh://git@bitbucket.org:22/team/repo.git", "bitbucket.org"},
+		{"git://git.kernel.org/pub/scm/git/git.git", "git.kernel.org"},
+		{"git@github.com:user/repo.git", "github.com"},
+		{"github.com:user/repo.git", "github.com"},
+		{"file:///srv/git/repo.git", ""},
+		{"/srv/git/repo.git", ""},
+		{"../repo.git", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, remoteHost(tt.url), "url %q", tt.url)
+	}
+}
@@ -0,0 +1,132 @@
+package gitstatus
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+const (
+	stashRefName    = plumbing.ReferenceName("refs/stash")
+	stashReflogPath = "logs/refs/stash"
+)
+
+// extractStashes records the repository's stash entries. The stash list lives in the reflog
+// of refs/stash, which go-git does not read, so the log file is parsed directly. If the
+// reflog is unavailable but refs/stash exists, the stash commit is reported as the only entry.
+func extractStashes(repo *git.Repository, status *models.GitStatus) {
+	stashRef, err := repo.Reference(stashRefName, false)
+	if err != nil || stashRef == nil {
+		return
+	}
+
+	status.HasStashes = true
+
+	var entries []models.StashEntry
+	if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		if data, err := util.ReadFile(storage.Filesystem(), stashReflogPath); err == nil {
+			entries = parseStashReflog(data)
+		}
+	}
+
+	if len(entries) == 0 {
+		commit, err := repo.CommitObject(stashRef.Hash())
+		if err != nil {
+			return
+		}
+
+		message, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		entries = []models.StashEntry{{
+			Branch:  stashBranch(message),
+			Message: message,
+			Date:    commit.Committer.When,
+		}}
+	}
+
+	status.Stashes = entries
+	status.StashCount = len(entries)
+}
+
+// parseStashReflog parses the reflog of refs/stash into stash entries, newest first.
+// Each line has the form "<old> <new> <name> <<email>> <unix-time> <tz>\t<message>";
+// malformed lines are skipped.
+func parseStashReflog(data []byte) []models.StashEntry {
+	var entries []models.StashEntry
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		header, message, found := strings.Cut(scanner.Text(), "\t")
+		if !found {
+			continue
+		}
+
+		date, ok := parseReflogDate(header)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, models.StashEntry{
+			Branch:  stashBranch(message),
+			Message: message,
+			Date:    date,
+		})
+	}
+
+	// The reflog is oldest first, while stash@{0} is the newest entry
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	for i := range entries {
+		entries[i].Index = i
+	}
+
+	return entries
+}
+
+// parseReflogDate extracts the "<unix-time> <tz>" pair that follows the email in a reflog header.
+func parseReflogDate(header string) (time.Time, bool) {
+	end := strings.LastIndex(header, ">")
+	if end < 0 {
+		return time.Time{}, false
+	}
+
+	fields := strings.Fields(header[end+1:])
+	if len(fields) != 2 { //nolint:mnd // unix time and timezone
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	zone, err := time.Parse("-0700", fields[1])
+	if err != nil {
+		return time.Unix(seconds, 0), true
+	}
+
+	return time.Unix(seconds, 0).In(zone.Location()), true
+}
+
+// stashBranch returns the branch named in a stash message such as "WIP on main: 1a2b3c4 Subject"
+// or "On feature: message", or an empty string if the message has another form.
+func stashBranch(message string) string {
+	for _, prefix := range []string{"WIP on ", "On "} {
+		if rest, found := strings.CutPrefix(message, prefix); found {
+			branch, _, found := strings.Cut(rest, ":")
+			if found {
+				return branch
+			}
+		}
+	}
+
+	return ""
+}
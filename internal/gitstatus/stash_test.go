@@ -0,0 +1,64 @@
+package gitstatus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testStashReflog = "0000000000000000000000000000000000000000 1111111111111111111111111111111111111111 " +
+	"Test User <test@example.com> 1700000000 +0100\tWIP on main: abc1234 Initial commit\n" +
+	"1111111111111111111111111111111111111111 2222222222222222222222222222222222222222 " +
+	"Test User <test@example.com> 1700003600 -0500\tOn feature/x: half-done parser\n" +
+	"malformed line without a tab\n"
+
+// Test parseStashReflog returns entries newest first with branch, message and date.
+func TestParseStashReflog(t *testing.T) {
+	entries := parseStashReflog([]byte(testStashReflog))
+
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, 0, entries[0].Index)
+	assert.Equal(t, "feature/x", entries[0].Branch)
+	assert.Equal(t, "On feature/x: half-done parser", entries[0].Message)
+	assert.True(t, entries[0].Date.Equal(time.Unix(1700003600, 0)))
+	_, offset := entries[0].Date.Zone()
+	assert.Equal(t, -5*60*60, offset)
+
+	assert.Equal(t, 1, entries[1].Index)
+	assert.Equal(t, "main", entries[1].Branch)
+	assert.Equal(t, "WIP on main: abc1234 Initial commit", entries[1].Message)
+	assert.True(t, entries[1].Date.Equal(time.Unix(1700000000, 0)))
+
+	assert.Empty(t, parseStashReflog(nil))
+}
+
+// Test stashBranch extracts the branch from the messages git stash writes.
+func TestStashBranch(t *testing.T) {
+	assert.Equal(t, "main", stashBranch("WIP on main: abc1234 Subject: with colon"))
+	assert.Equal(t, "feature", stashBranch("On feature: custom message"))
+	assert.Equal(t, "(no branch)", stashBranch("WIP on (no branch): abc1234 Subject"))
+	assert.Empty(t, stashBranch("autostash"))
+}
+
+// Test Extract() counts every entry of the stash reflog.
+func TestExtract_StashReflog(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-stash")
+	logDir := filepath.Join(repoPath, ".git", "logs", "refs")
+	require.NoError(t, os.MkdirAll(logDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(logDir, "stash"), []byte(testStashReflog), 0o600))
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+
+	require.NoError(t, err)
+	assert.True(t, status.HasStashes)
+	assert.Equal(t, 2, status.StashCount)
+	require.Len(t, status.Stashes, 2)
+	assert.Equal(t, "On feature/x: half-done parser", status.Stashes[0].Message)
+}
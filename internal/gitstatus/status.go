@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,7 +18,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
-	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 )
 
 // ExtractOptions configures the Git status extraction behavior.
@@ -28,23 +29,57 @@ type ExtractOptions struct {
 	// MaxConcurrency limits the number of repositories processed concurrently in ExtractBatch
 	MaxConcurrency int
 
+	// MaxConcurrencyPerHost limits the number of concurrent fetches from one remote host; zero is unlimited
+	MaxConcurrencyPerHost int
+
+	// HostFailureLimit is the number of consecutive connection failures to a remote host after which
+	// the remaining fetches from it are skipped as unreachable; zero never skips
+	HostFailureLimit int
+
 	// Debug enables debug output for status extraction operations
 	Debug bool
 
-	// Fetch enables fetching from origin remote before calculating ahead/behind counts
+	// Fetch enables fetching remotes (origin unless FetchAllRemotes or FetchRemotes is set) before calculating ahead/behind counts
 	Fetch bool
 
 	// FetchRetries is the number of retry attempts for failed fetch operations
 	FetchRetries int
+
+	// FetchAllRemotes fetches every configured remote instead of only origin
+	FetchAllRemotes bool
+
+	// FetchRemotes names the remotes to fetch instead of only origin; remotes a repository lacks are skipped
+	FetchRemotes []string
+
+	// Prune removes remote-tracking references whose branches were deleted on the remote
+	Prune bool
+
+	// FetchTags selects the tags fetched; empty means FetchTagsFollow
+	FetchTags FetchTagsPolicy
+
+	// FetchCurrentBranch narrows each fetch to the branch the checked-out branch tracks
+	FetchCurrentBranch bool
+
+	// FetchMaxAge skips fetching repositories fetched more recently than this; zero always fetches
+	FetchMaxAge time.Duration
+
+	// Branches enables reporting every local branch, with its unpushed commits, in GitStatus.Branches
+	Branches bool
+
+	// OnResult, if set, is called by ExtractBatch as soon as each repository's status is available.
+	// Calls are made sequentially from the goroutine that invoked ExtractBatch, so the callback
+	// does not need to be safe for concurrent use.
+	OnResult func(path string, status *models.GitStatus)
 }
 
 const (
 	defaultExtractTimeout  = 10 * time.Second
 	defaultMaxConcurrency  = 10
 	defaultFetchRetries    = 3
+	defaultMaxPerHost      = 8
+	defaultHostFailures    = 3
 	maxFilesPerCategory    = 20
 	thresholdSlowOperation = 100 * time.Millisecond
-	maxCommitsToCount      = 100 // Limit commit scanning to prevent unbounded memory usage
 )
 
 var (
@@ -71,10 +106,12 @@ func debugPrintf(format string, args ...any) {
 // DefaultOptions returns sensible default options.
 func DefaultOptions() *ExtractOptions {
 	return &ExtractOptions{
-		Timeout:        defaultExtractTimeout,
-		MaxConcurrency: defaultMaxConcurrency,
-		Fetch:          true,
-		FetchRetries:   defaultFetchRetries,
+		Timeout:               defaultExtractTimeout,
+		MaxConcurrency:        defaultMaxConcurrency,
+		MaxConcurrencyPerHost: defaultMaxPerHost,
+		HostFailureLimit:      defaultHostFailures,
+		Fetch:                 true,
+		FetchRetries:          defaultFetchRetries,
 	}
 }
 
@@ -140,7 +177,7 @@ func extractGitStatus(
 	startTime := time.Now()
 
 	// Open repository
-	repo, err := git.PlainOpen(repoPath)
+	repo, err := openRepository(repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
@@ -156,22 +193,33 @@ func extractGitStatus(
 	if err := extractBranch(repo, status); err != nil {
 		status.Branch = "N/A"
 		status.Error = err.Error()
+	} else {
+		// Non-fatal: the commit date is informational only
+		_ = extractLastCommit(repo, status)
 	}
 
+	// Detect a stopped merge, rebase, cherry-pick, revert or bisect
+	status.Operation = extractOperation(repo)
+
 	// Check for remote
 	if err := extractRemote(repo, status); err != nil {
 		// Non-fatal: just means no remote
 		status.HasRemote = false
 	}
+	extractDefaultBranch(repo, status)
 
 	// Check for cancellation before expensive ahead/behind calculation
 	if err := checkContext(ctx); err != nil {
 		return nil, err
 	}
 
+	// The ahead/behind comparisons and the branch report share one commit-graph reader
+	graph := newCommitGraph(repo)
+	defer graph.Close()
+
 	// Extract ahead/behind counts if remote exists
 	if status.HasRemote {
-		if err := extractAheadBehind(repo, status); err != nil {
+		if err := extractAheadBehind(repo, graph, status); err != nil {
 			// Non-fatal: log error but continue
 			if status.Error == "" {
 				status.Error = err.Error()
@@ -179,8 +227,22 @@ func extractGitStatus(
 		}
 	}
 
+	// Compare with the remote default branch
+	if status.HasRemote {
+		if err := extractBaseAheadBehind(repo, graph, status); err != nil && status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+
+	// Examine every local branch, not just HEAD
+	if opts.Branches {
+		if err := extractBranches(repo, graph, status); err != nil && status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+
 	// Check for stashes
-	status.HasStashes = extractStashes(repo)
+	extractStashes(repo, status)
 
 	// Check for cancellation before expensive worktree status scan
 	if err := checkContext(ctx); err != nil {
@@ -197,6 +259,21 @@ func extractGitStatus(
 		}
 	}
 
+	// Check for cancellation before inspecting submodules
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	// Check submodule checkouts against the commits recorded in the index
+	if err := extractSubmodules(ctx, repo, repoPath, status, opts, ignorePatterns); err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+		if status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+
 	// Debug output
 	if opts.Debug {
 		printDebugSummary(repoPath, status, startTime)
@@ -205,6 +282,13 @@ func extractGitStatus(
 	return status, nil
 }
 
+// openRepository opens the repository at path. A .git file is followed to the git directory it
+// names, and the commondir of linked worktrees is honored so refs, objects and the stash are
+// read from the shared git directory.
+func openRepository(path string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(path, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+}
+
 // printDebugSummary prints debug timing and status summary.
 func printDebugSummary(repoPath string, status *models.GitStatus, startTime time.Time) {
 	// Timing (only if >100ms)
@@ -215,6 +299,9 @@ func printDebugSummary(repoPath string, status *models.GitStatus, startTime time
 
 	// Status summary
 	statusParts := []string{"branch=" + status.Branch}
+	if status.Operation != "" {
+		statusParts = append(statusParts, "operation="+status.Operation)
+	}
 	statusParts = append(statusParts, fmt.Sprintf("hasChanges=%t", status.HasChanges))
 	if status.HasRemote {
 		statusParts = append(statusParts, "hasRemote=true")
@@ -228,7 +315,7 @@ func printDebugSummary(repoPath string, status *models.GitStatus, startTime time
 		statusParts = append(statusParts, "hasRemote=false")
 	}
 	if status.HasStashes {
-		statusParts = append(statusParts, "hasStashes=true")
+		statusParts = append(statusParts, fmt.Sprintf("stashes=%d", status.StashCount))
 	}
 
 	debugPrintf("Repository %s: %s", repoPath, strings.Join(statusParts, ", "))
@@ -252,6 +339,41 @@ func extractBranch(repo *git.Repository, status *models.GitStatus) error {
 	return nil
 }
 
+// extractLastCommit records metadata of the HEAD commit.
+func extractLastCommit(repo *git.Repository, status *models.GitStatus) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+
+	subject, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+	status.LastCommitHash = commit.Hash.String()
+	status.LastCommitSubject = strings.TrimSpace(subject)
+	status.LastCommitAuthor = commit.Author.Name
+	status.LastCommitDate = commit.Committer.When
+
+	return nil
+}
+
+// extractDefaultBranch records the default branch named by refs/remotes/origin/HEAD, the
+// symbolic ref git clone and git remote set-head write. Nothing is recorded if it is missing.
+func extractDefaultBranch(repo *git.Repository, status *models.GitStatus) {
+	ref, err := repo.Reference(plumbing.NewRemoteHEADReferenceName(originRemote), false)
+	if err != nil || ref.Type() != plumbing.SymbolicReference {
+		return
+	}
+
+	prefix := "refs/remotes/" + originRemote + "/"
+	if branch, found := strings.CutPrefix(ref.Target().String(), prefix); found {
+		status.DefaultBranch = branch
+	}
+}
+
 // extractRemote checks if the repository has a remote configured.
 func extractRemote(repo *git.Repository, status *models.GitStatus) error {
 	remotes, err := repo.Remotes()
@@ -261,6 +383,9 @@ func extractRemote(repo *git.Repository, status *models.GitStatus) error {
 
 	if len(remotes) > 0 {
 		status.HasRemote = true
+		if remote := primaryRemote(remotes); remote != nil && len(remote.Config().URLs) > 0 {
+			status.RemoteHost = remoteHost(remote.Config().URLs[0])
+		}
 
 		return nil
 	}
@@ -271,18 +396,32 @@ func extractRemote(repo *git.Repository, status *models.GitStatus) error {
 }
 
 // extractAheadBehind calculates commits ahead and behind the remote tracking branch.
-func extractAheadBehind(repo *git.Repository, status *models.GitStatus) error {
+func extractAheadBehind(repo *git.Repository, graph *commitGraph, status *models.GitStatus) error {
 	// Get local HEAD
 	head, err := repo.Head()
 	if err != nil {
 		return err
 	}
 
+	// A detached HEAD has no upstream to compare against
+	if !head.Name().IsBranch() {
+		return nil
+	}
+
 	// Get remote tracking branch
-	branchName := head.Name().Short()
-	remoteBranchRefName := plumbing.NewRemoteReferenceName("origin", branchName)
+	remoteBranchRefName, configured, err := resolveUpstream(repo, head.Name())
+	if err != nil {
+		return err
+	}
 
-	remoteRef, err := repo.Reference(remoteBranchRefName, false)
+	remoteRef, err := repo.Reference(remoteBranchRefName, true)
+	if configured && errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// The configured upstream was deleted on the remote and pruned
+		status.Upstream = remoteBranchRefName.Short()
+		status.UpstreamGone = true
+
+		return nil
+	}
 	if err != nil {
 		// No remote tracking branch
 		status.Ahead = 0
@@ -290,121 +429,93 @@ func extractAheadBehind(repo *git.Repository, status *models.GitStatus) error {
 
 		return err
 	}
+	status.Upstream = remoteBranchRefName.Short()
 
-	// Count commits between local and remote
-	localCommit, err := repo.CommitObject(head.Hash())
+	// Count commits in local not in remote (ahead) and in remote not in local (behind)
+	ahead, behind, err := graph.aheadBehind(head.Hash(), remoteRef.Hash())
 	if err != nil {
 		return err
 	}
+	status.Ahead = ahead
+	status.Behind = behind
 
-	remoteCommit, err := repo.CommitObject(remoteRef.Hash())
-	if err != nil {
-		return err
+	return nil
+}
+
+// extractBaseAheadBehind calculates how far HEAD has diverged from the remote default branch:
+// origin/<DefaultBranch> when origin/HEAD names it, otherwise origin/main or origin/master.
+// Nothing is recorded when there is no such branch or it is HEAD's upstream, whose counts
+// are already reported as Ahead and Behind.
+func extractBaseAheadBehind(repo *git.Repository, graph *commitGraph, status *models.GitStatus) error {
+	baseRef := resolveBaseBranch(repo, status.DefaultBranch)
+	if baseRef == nil || baseRef.Name().Short() == status.Upstream {
+		return nil
 	}
 
-	// Count ahead (commits in local not in remote)
-	ahead, err := countCommitsBetween(repo, localCommit, remoteCommit)
+	head, err := repo.Head()
 	if err != nil {
 		return err
 	}
-	status.Ahead = ahead
 
-	// Count behind (commits in remote not in local)
-	behind, err := countCommitsBetween(repo, remoteCommit, localCommit)
+	ahead, behind, err := graph.aheadBehind(head.Hash(), baseRef.Hash())
 	if err != nil {
 		return err
 	}
-	status.Behind = behind
+
+	status.BaseBranch = baseRef.Name().Short()
+	status.BaseAhead = ahead
+	status.BaseBehind = behind
 
 	return nil
 }
 
-// countCommitsBetween counts commits from 'from' to the merge-base with 'to'.
-// Uses BFS to properly handle merge commits.
-func countCommitsBetween(_ *git.Repository, from, to *object.Commit) (count int, err error) {
-	// Find merge-base (common ancestor)
-	mergeBaseCommits, err := from.MergeBase(to)
-	if err != nil {
-		return 0, fmt.Errorf("failed to find merge-base: %w", err)
+// resolveBaseBranch returns the remote-tracking reference of the default branch, or nil if
+// none of the candidates exists.
+func resolveBaseBranch(repo *git.Repository, defaultBranch string) *plumbing.Reference {
+	candidates := []string{"main", "master"}
+	if defaultBranch != "" {
+		candidates = []string{defaultBranch}
 	}
 
-	if len(mergeBaseCommits) == 0 {
-		// No common ancestor - count all commits from 'from' (up to limit)
-		return countCommitsToRoot(from)
-	}
-
-	// Build set of merge-base hashes for quick lookup
-	mergeBaseSet := make(map[plumbing.Hash]bool)
-	for _, mb := range mergeBaseCommits {
-		mergeBaseSet[mb.Hash] = true
-	}
-
-	// BFS to count all commits reachable from 'from' until merge-base
-	visited := make(map[plumbing.Hash]bool)
-	queue := []*object.Commit{from}
-
-	for len(queue) > 0 && count < maxCommitsToCount {
-		commit := queue[0]
-		queue = queue[1:]
-
-		// Skip if already visited or is a merge-base
-		if visited[commit.Hash] || mergeBaseSet[commit.Hash] {
-			continue
-		}
-
-		visited[commit.Hash] = true
-		count++
-
-		// Add all parents to queue
-		for i := range commit.NumParents() {
-			parent, parentErr := commit.Parent(i)
-			if parentErr != nil {
-				continue
-			}
-
-			if !visited[parent.Hash] && !mergeBaseSet[parent.Hash] {
-				queue = append(queue, parent)
-			}
+	for _, branch := range candidates {
+		ref, err := repo.Reference(plumbing.NewRemoteReferenceName(originRemote, branch), true)
+		if err == nil {
+			return ref
 		}
 	}
 
-	return count, nil
+	return nil
 }
 
-// countCommitsToRoot counts commits from a commit to root (up to limit).
-func countCommitsToRoot(from *object.Commit) (int, error) {
-	count := 0
-	commit := from
-
-	for commit != nil {
-		count++
-		if count >= maxCommitsToCount {
-			break
-		}
-
-		if commit.NumParents() == 0 {
-			break
-		}
-
-		parent, parentErr := commit.Parent(0)
-		if parentErr != nil {
-			return count, nil //nolint:nilerr // Intentionally return partial count
-		}
+// resolveUpstream returns the reference a branch is compared against and whether it is
+// configured. It uses the branch's configured upstream (branch.<name>.remote and
+// branch.<name>.merge), mapped through the remote's fetch refspecs, and falls back to
+// origin/<branch> when no upstream is configured.
+func resolveUpstream(repo *git.Repository, branch plumbing.ReferenceName) (plumbing.ReferenceName, bool, error) {
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read repository config: %w", err)
+	}
 
-		commit = parent
+	branchCfg, exists := cfg.Branches[branch.Short()]
+	if !exists || branchCfg.Remote == "" || branchCfg.Merge == "" {
+		return plumbing.NewRemoteReferenceName(originRemote, branch.Short()), false, nil
 	}
 
-	return count, nil
-}
+	// A "." remote means the branch tracks another local branch
+	if branchCfg.Remote == "." {
+		return branchCfg.Merge, true, nil
+	}
 
-// extractStashes checks if the repository has any stashed changes.
-func extractStashes(repo *git.Repository) bool {
-	stashRef, err := repo.Reference("refs/stash", false)
-	if err != nil {
-		return false
+	if remoteCfg, exists := cfg.Remotes[branchCfg.Remote]; exists {
+		for _, refSpec := range remoteCfg.Fetch {
+			if refSpec.Match(branchCfg.Merge) {
+				return refSpec.Dst(branchCfg.Merge), true, nil
+			}
+		}
 	}
 
-	return stashRef != nil
+	return plumbing.NewRemoteReferenceName(branchCfg.Remote, branchCfg.Merge.Short()), true, nil
 }
 
 // readGitignoreFile reads a gitignore file directly and returns patterns.
@@ -443,26 +554,71 @@ func readGitignoreFile(path string) ([]gitignore.Pattern, error) {
 	return patterns, nil
 }
 
-// categorizeAndPrintFiles categorizes and prints files with truncation.
-func categorizeAndPrintFiles(wtStatus git.Status) {
-	modifiedFiles := []string{}
-	untrackedFiles := []string{}
-	stagedFiles := []string{}
-	deletedFiles := []string{}
+// fileCategories groups changed files by category, mirroring models.ChangeCounts.
+type fileCategories struct {
+	staged     []string
+	modified   []string
+	deleted    []string
+	renamed    []string
+	untracked  []string
+	conflicted []string
+}
+
+// categorizeFiles sorts worktree status entries into change categories. A file that is
+// staged and modified again appears in both categories; conflicted and untracked files
+// appear only in their own category.
+func categorizeFiles(wtStatus git.Status) fileCategories {
+	var fc fileCategories
 
 	for filename, fileStatus := range wtStatus {
 		switch {
-		case fileStatus.Worktree == git.Modified && fileStatus.Staging == git.Unmodified:
-			modifiedFiles = append(modifiedFiles, filename)
+		case fileStatus.Staging == git.UpdatedButUnmerged || fileStatus.Worktree == git.UpdatedButUnmerged:
+			fc.conflicted = append(fc.conflicted, filename)
+
+			continue
 		case fileStatus.Staging == git.Untracked && fileStatus.Worktree == git.Untracked:
-			untrackedFiles = append(untrackedFiles, filename)
-		case fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked:
-			stagedFiles = append(stagedFiles, filename)
-		case fileStatus.Worktree == git.Deleted:
-			deletedFiles = append(deletedFiles, filename)
+			fc.untracked = append(fc.untracked, filename)
+
+			continue
+		}
+
+		switch fileStatus.Staging {
+		case git.Unmodified, git.Untracked:
+		case git.Renamed:
+			fc.renamed = append(fc.renamed, filename)
+		default:
+			fc.staged = append(fc.staged, filename)
 		}
+
+		switch fileStatus.Worktree { //nolint:exhaustive // Other worktree codes carry no unstaged change
+		case git.Modified:
+			fc.modified = append(fc.modified, filename)
+		case git.Deleted:
+			fc.deleted = append(fc.deleted, filename)
+		}
+	}
+
+	for _, files := range []*[]string{&fc.staged, &fc.modified, &fc.deleted, &fc.renamed, &fc.untracked, &fc.conflicted} {
+		sort.Strings(*files)
+	}
+
+	return fc
+}
+
+// counts returns the number of files in each category.
+func (fc fileCategories) counts() models.ChangeCounts {
+	return models.ChangeCounts{
+		Staged:     len(fc.staged),
+		Modified:   len(fc.modified),
+		Deleted:    len(fc.deleted),
+		Renamed:    len(fc.renamed),
+		Untracked:  len(fc.untracked),
+		Conflicted: len(fc.conflicted),
 	}
+}
 
+// printFileCategories prints categorized files with truncation.
+func printFileCategories(fc fileCategories) {
 	printFileList := func(category string, files []string) {
 		if len(files) == 0 {
 			return
@@ -475,10 +631,12 @@ func categorizeAndPrintFiles(wtStatus git.Status) {
 		}
 	}
 
-	printFileList("Modified", modifiedFiles)
-	printFileList("Untracked", untrackedFiles)
-	printFileList("Staged", stagedFiles)
-	printFileList("Deleted", deletedFiles)
+	printFileList("Modified", fc.modified)
+	printFileList("Untracked", fc.untracked)
+	printFileList("Staged", fc.staged)
+	printFileList("Deleted", fc.deleted)
+	printFileList("Renamed", fc.renamed)
+	printFileList("Conflicted", fc.conflicted)
 }
 
 // loadGlobalIgnorePatterns loads global gitignore patterns from core.excludesfile and default locations.
@@ -597,14 +755,48 @@ func extractUncommittedChanges(
 	}
 
 	status.HasChanges = !wtStatus.IsClean()
+	status.WorktreeModified = latestWorktreeModification(repo, worktree.Filesystem, wtStatus)
+
+	categories := categorizeFiles(wtStatus)
+	status.Changes = categories.counts()
 
 	if opts.Debug && status.HasChanges {
-		categorizeAndPrintFiles(wtStatus)
+		printFileCategories(categories)
 	}
 
 	return nil
 }
 
+// latestWorktreeModification returns the most recent modification time among changed
+// worktree files and the index, which is rewritten on every stage, commit and checkout.
+// Deleted files are skipped; the zero time is returned if nothing could be examined.
+func latestWorktreeModification(repo *git.Repository, wtFS billy.Filesystem, wtStatus git.Status) time.Time {
+	var latest time.Time
+
+	for path, fileStatus := range wtStatus {
+		if fileStatus.Worktree == git.Unmodified && fileStatus.Staging == git.Unmodified {
+			continue
+		}
+
+		info, err := wtFS.Lstat(path)
+		if err != nil {
+			continue
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		if info, err := storage.Filesystem().Stat("index"); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest
+}
+
 // ExtractBatch extracts Git status for multiple repositories concurrently.
 func ExtractBatch(
 	ctx context.Context, repos map[string]*models.Repository, opts *ExtractOptions,
@@ -622,17 +814,44 @@ func ExtractBatch(
 		return batchResult
 	}
 
-	// Fetch phase (if enabled)
+	// Each repository is extracted as soon as its own fetch is done, so results can be streamed
+	// through OnResult while slower remotes are still being fetched
+	ready := make(chan string, len(repos))
 	if opts.Fetch {
-		if opts.Debug {
-			debugPrintf("Starting fetch phase for %d repositories", len(repos))
-		}
-		fetchBatch(ctx, repos, opts, batchResult)
-		if opts.Debug {
-			stats := batchResult.FetchStats
-			debugPrintf("Fetch complete: %d attempted, %d successful, %d skipped, %d failed",
-				stats.TotalAttempted, stats.Successful, stats.Skipped, stats.Failed)
+		batchResult.FetchStats = &models.FetchStats{}
+		go func() {
+			defer close(ready)
+
+			if opts.Debug {
+				debugPrintf("Starting fetch phase for %d repositories", len(repos))
+			}
+
+			released := make(map[string]bool, len(repos))
+			fetchBatch(ctx, repos, opts, batchResult, func(paths []string) {
+				for _, path := range paths {
+					released[path] = true
+					ready <- path
+				}
+			})
+
+			// Repositories that were not fetched are extracted as they are
+			for path := range repos {
+				if !released[path] {
+					ready <- path
+				}
+			}
+
+			if opts.Debug {
+				stats := batchResult.FetchStats
+				debugPrintf("Fetch complete: %d attempted, %d successful, %d skipped, %d fresh, %d failed",
+					stats.TotalAttempted, stats.Successful, stats.Skipped, stats.Fresh, stats.Failed)
+			}
+		}()
+	} else {
+		for path := range repos {
+			ready <- path
 		}
+		close(ready)
 	}
 
 	// Load global gitignore patterns once for all repositories
@@ -658,40 +877,49 @@ func ExtractBatch(
 
 	var wg sync.WaitGroup
 
-	// Launch workers for each repository
-	for path := range repos {
-		wg.Add(1)
-
-		go func(repoPath string) {
-			defer wg.Done()
-
-			// Check context before starting
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-
-			// Acquire semaphore
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }()
-			case <-ctx.Done():
-				return
-			}
-
-			// Extract status
-			status, err := Extract(ctx, repoPath, opts, ignorePatterns)
-			results <- result{
-				path:   repoPath,
-				status: status,
-				err:    err,
-			}
-		}(path)
-	}
+	// Launch a worker for each repository once it is ready
+	launched := make(chan struct{})
+	go func() {
+		defer close(launched)
+
+		for path := range ready {
+			wg.Add(1)
+
+			go func(repoPath string) {
+				defer wg.Done()
+
+				// Check context before starting
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				// Acquire semaphore
+				select {
+				case semaphore <- struct{}{}:
+					defer func() { <-semaphore }()
+				case <-ctx.Done():
+					return
+				}
+
+				// Extract status
+				status, err := Extract(ctx, repoPath, opts, ignorePatterns)
+				if status != nil && opts.Fetch {
+					attachFetchError(repos[repoPath], status)
+				}
+				results <- result{
+					path:   repoPath,
+					status: status,
+					err:    err,
+				}
+			}(path)
+		}
+	}()
 
-	// Wait for all workers to finish
+	// Wait for all workers to be launched and to finish
 	go func() {
+		<-launched
 		wg.Wait()
 		close(results)
 	}()
@@ -706,6 +934,10 @@ func ExtractBatch(
 			} else {
 				batchResult.SuccessCount++
 			}
+
+			if opts.OnResult != nil {
+				opts.OnResult(r.path, r.status)
+			}
 		} else if r.err != nil {
 			// Fallback if status is nil but error is present (though Extract should handle this)
 			batchResult.FailedRepos = append(batchResult.FailedRepos, r.path)
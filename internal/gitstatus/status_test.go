@@ -116,6 +116,46 @@ func createTestRepoWithState(t *testing.T, state string) string {
 		err = repo.Storer.SetReference(remoteRef)
 		require.NoError(t, err)
 
+	case "with-upstream":
+		// Fork workflow: the branch tracks upstream/trunk, while origin has a same-name branch
+		for _, name := range []string{"origin", "upstream"} {
+			_, err = repo.CreateRemote(&config.RemoteConfig{
+				Name: name,
+				URLs: []string{"https://github.com/" + name + "/repo.git"},
+			})
+			require.NoError(t, err)
+		}
+
+		head, err := repo.Head()
+		require.NoError(t, err)
+		branchName := head.Name().Short()
+		firstCommitHash := head.Hash()
+
+		err = repo.CreateBranch(&config.Branch{
+			Name:   branchName,
+			Remote: "upstream",
+			Merge:  plumbing.NewBranchReferenceName("trunk"),
+		})
+		require.NoError(t, err)
+
+		testFile2 := filepath.Join(tempDir, "test2.txt")
+		err = os.WriteFile(testFile2, []byte("new file"), 0o600)
+		require.NoError(t, err)
+		_, err = worktree.Add("test2.txt")
+		require.NoError(t, err)
+		secondCommitHash, err := worktree.Commit("Second commit", &git.CommitOptions{
+			Author: sig,
+		})
+		require.NoError(t, err)
+
+		// upstream/trunk is one commit behind HEAD; origin/<branch> matches HEAD
+		err = repo.Storer.SetReference(plumbing.NewHashReference(
+			plumbing.NewRemoteReferenceName("upstream", "trunk"), firstCommitHash))
+		require.NoError(t, err)
+		err = repo.Storer.SetReference(plumbing.NewHashReference(
+			plumbing.NewRemoteReferenceName("origin", branchName), secondCommitHash))
+		require.NoError(t, err)
+
 	case "bare": //nolint:goconst // for clarity
 		// Close current repo and create bare repo
 		tempDirBare := t.TempDir()
@@ -169,6 +209,19 @@ func TestExtract_GetsBranchName(t *testing.T) {
 	// Branch can be "main" or "master" depending on Git version
 	assert.Contains(t, []string{"main", "master"}, status.Branch)
 	assert.False(t, status.IsDetached)
+	assert.False(t, status.LastCommitDate.IsZero(), "should record the HEAD commit date")
+}
+
+// Test Extract() records the hash, subject and author of the HEAD commit.
+func TestExtract_RecordsLastCommit(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "basic")
+	hash := commitTestFile(t, repoPath, "notes.txt", "notes")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), status.LastCommitHash)
+	assert.Equal(t, "Add notes.txt", status.LastCommitSubject)
+	assert.Equal(t, "Test User", status.LastCommitAuthor)
 }
 
 // T034: Test Extract() detecting detached HEAD.
@@ -196,6 +249,210 @@ func TestExtract_CalculatesAheadBehindCounts(t *testing.T) {
 	assert.Equal(t, 1, status.Ahead, "should be 1 commit ahead")
 	assert.Equal(t, 0, status.Behind, "should be 0 commits behind")
 	assert.True(t, status.HasRemote)
+	assert.Contains(t, []string{"origin/main", "origin/master"}, status.Upstream)
+}
+
+// Test Extract() compares against the configured upstream rather than origin/<branch>.
+func TestExtract_UsesConfiguredUpstream(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-upstream")
+
+	ctx := context.Background()
+	status, err := Extract(ctx, repoPath, nil, []gitignore.Pattern{})
+
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, "upstream/trunk", status.Upstream)
+	assert.Equal(t, 1, status.Ahead, "should be 1 commit ahead of upstream/trunk")
+	assert.Equal(t, 0, status.Behind)
+	assert.Empty(t, status.Error)
+}
+
+// Test Extract() reports a configured upstream that no longer exists as gone instead of an error.
+func TestExtract_DetectsGoneUpstream(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-remote")
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	err = repo.CreateBranch(&config.Branch{Name: "master", Remote: "origin", Merge: plumbing.NewBranchReferenceName("master")})
+	require.NoError(t, err)
+
+	opts := DefaultOptions()
+	opts.Branches = true
+	status, err := Extract(context.Background(), repoPath, opts, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.True(t, status.UpstreamGone)
+	assert.Equal(t, "origin/master", status.Upstream)
+	assert.Equal(t, models.SyncGone, status.SyncState())
+	assert.Empty(t, status.Error)
+	require.Len(t, status.Branches, 1)
+	assert.True(t, status.Branches[0].UpstreamGone)
+	assert.Equal(t, "origin/master", status.Branches[0].Upstream)
+
+	// An unconfigured branch without origin/<branch> has simply never been pushed
+	repoPath = createTestRepoWithState(t, "with-remote")
+	status, err = Extract(context.Background(), repoPath, opts, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.False(t, status.UpstreamGone)
+	require.Len(t, status.Branches, 1)
+	assert.False(t, status.Branches[0].UpstreamGone)
+	assert.Empty(t, status.Branches[0].Upstream)
+}
+
+// Test Extract() reads the default branch from refs/remotes/origin/HEAD.
+func TestExtract_DetectsDefaultBranch(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-remote")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Empty(t, status.DefaultBranch, "no origin/HEAD yet")
+
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	err = repo.Storer.SetReference(plumbing.NewSymbolicReference(
+		plumbing.NewRemoteHEADReferenceName("origin"), plumbing.NewRemoteReferenceName("origin", "develop")))
+	require.NoError(t, err)
+
+	status, err = Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Equal(t, "develop", status.DefaultBranch)
+}
+
+// Test Extract() compares a feature branch with the remote default branch.
+func TestExtract_CalculatesBaseAheadBehind(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-remote")
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	forkPoint := head.Hash()
+
+	// origin/main moves one commit past the fork point
+	mainHead := commitTestFile(t, repoPath, "main.txt", "main")
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "main"), mainHead))
+	require.NoError(t, err)
+
+	// The feature branch adds two commits of its own
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	err = worktree.Checkout(&git.CheckoutOptions{Hash: forkPoint, Branch: plumbing.NewBranchReferenceName("feature"), Create: true})
+	require.NoError(t, err)
+	commitTestFile(t, repoPath, "feature1.txt", "one")
+	commitTestFile(t, repoPath, "feature2.txt", "two")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Equal(t, "origin/main", status.BaseBranch, "origin/main is used without origin/HEAD")
+	assert.Equal(t, 2, status.BaseAhead)
+	assert.Equal(t, 1, status.BaseBehind)
+
+	// A default branch without a remote-tracking ref disables the comparison
+	err = repo.Storer.SetReference(plumbing.NewSymbolicReference(
+		plumbing.NewRemoteHEADReferenceName("origin"), plumbing.NewRemoteReferenceName("origin", "develop")))
+	require.NoError(t, err)
+
+	status, err = Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Empty(t, status.BaseBranch)
+	assert.Zero(t, status.BaseAhead)
+	assert.Zero(t, status.BaseBehind)
+}
+
+// Test Extract() skips the base comparison when the default branch is the upstream.
+func TestExtract_SkipsBaseForUpstream(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-ahead")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Equal(t, "origin/master", status.Upstream)
+	assert.Equal(t, 1, status.Ahead)
+	assert.Empty(t, status.BaseBranch)
+}
+
+// Test resolveUpstream maps the merge ref through the remote's fetch refspec.
+func TestResolveUpstream(t *testing.T) {
+	repo, err := git.PlainInit(t.TempDir(), false)
+	require.NoError(t, err)
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name:  "fork",
+		URLs:  []string{"https://github.com/fork/repo.git"},
+		Fetch: []config.RefSpec{"+refs/heads/*:refs/remotes/mirror/*"},
+	})
+	require.NoError(t, err)
+
+	for _, branch := range []*config.Branch{
+		{Name: "mapped", Remote: "fork", Merge: plumbing.NewBranchReferenceName("dev")},
+		{Name: "local", Remote: ".", Merge: plumbing.NewBranchReferenceName("main")},
+		{Name: "unknown-remote", Remote: "gone", Merge: plumbing.NewBranchReferenceName("main")},
+	} {
+		require.NoError(t, repo.CreateBranch(branch))
+	}
+
+	tests := []struct {
+		branch     string
+		want       plumbing.ReferenceName
+		configured bool
+	}{
+		{"mapped", "refs/remotes/mirror/dev", true},
+		{"local", "refs/heads/main", true},
+		{"unknown-remote", "refs/remotes/gone/main", true},
+		{"unconfigured", "refs/remotes/origin/unconfigured", false},
+	}
+
+	for _, tt := range tests {
+		got, configured, err := resolveUpstream(repo, plumbing.NewBranchReferenceName(tt.branch))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "branch %s", tt.branch)
+		assert.Equal(t, tt.configured, configured, "branch %s", tt.branch)
+	}
+}
+
+// addTestWorktree registers a linked worktree of the repository at repoPath on a new branch,
+// laid out the way git worktree add does it, and returns the worktree path.
+func addTestWorktree(t *testing.T, repoPath, branch string) string {
+	t.Helper()
+
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	err = repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), head.Hash()))
+	require.NoError(t, err)
+
+	worktreePath := t.TempDir()
+	adminDir := filepath.Join(repoPath, ".git", "worktrees", branch)
+	require.NoError(t, os.MkdirAll(adminDir, 0o750))
+	for name, content := range map[string]string{
+		"HEAD":      "ref: refs/heads/" + branch + "\n",
+		"commondir": "../..\n",
+		"gitdir":    filepath.Join(worktreePath, ".git") + "\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(adminDir, name), []byte(content), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, ".git"), []byte("gitdir: "+adminDir+"\n"), 0o600))
+
+	return worktreePath
+}
+
+// Test Extract() reads a linked worktree's own HEAD and state and the shared refs.
+func TestExtract_LinkedWorktree(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-stash")
+	worktreePath := addTestWorktree(t, repoPath, "feature")
+
+	// Operation state files are per worktree
+	err := os.WriteFile(filepath.Join(repoPath, ".git", "worktrees", "feature", "MERGE_HEAD"), []byte("0\n"), 0o600)
+	require.NoError(t, err)
+
+	status, err := Extract(context.Background(), worktreePath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Equal(t, "feature", status.Branch)
+	assert.Equal(t, "MERGING", status.Operation)
+	assert.True(t, status.HasStashes, "the stash is shared through the common git directory")
+	assert.False(t, status.LastCommitDate.IsZero())
+
+	mainStatus, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.NotEqual(t, "feature", mainStatus.Branch)
+	assert.Empty(t, mainStatus.Operation)
 }
 
 // T036: Test Extract() detecting no remote.
@@ -222,6 +479,10 @@ func TestExtract_DetectsStashes(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, status)
 	assert.True(t, status.HasStashes)
+	// Without a stash reflog the stash commit is the only entry
+	assert.Equal(t, 1, status.StashCount)
+	require.Len(t, status.Stashes, 1)
+	assert.False(t, status.Stashes[0].Date.IsZero())
 }
 
 // T038: Test Extract() detecting uncommitted changes.
@@ -234,6 +495,35 @@ func TestExtract_DetectsUncommittedChanges(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, status)
 	assert.True(t, status.HasChanges)
+	assert.False(t, status.WorktreeModified.IsZero(), "should record the latest worktree modification")
+	assert.Equal(t, models.ChangeCounts{Modified: 1}, status.Changes)
+}
+
+// Test categorizeFiles counts each change category from the worktree status.
+func TestCategorizeFiles(t *testing.T) {
+	wtStatus := git.Status{
+		"staged.go":         {Staging: git.Added, Worktree: git.Unmodified},
+		"staged-again.go":   {Staging: git.Modified, Worktree: git.Modified},
+		"modified.go":       {Staging: git.Unmodified, Worktree: git.Modified},
+		"deleted.go":        {Staging: git.Unmodified, Worktree: git.Deleted},
+		"renamed.go":        {Staging: git.Renamed, Worktree: git.Unmodified},
+		"scratch.txt":       {Staging: git.Untracked, Worktree: git.Untracked},
+		"notes.txt":         {Staging: git.Untracked, Worktree: git.Untracked},
+		"conflict.go":       {Staging: git.UpdatedButUnmerged, Worktree: git.UpdatedButUnmerged},
+		"staged-deleted.go": {Staging: git.Deleted, Worktree: git.Unmodified},
+	}
+
+	categories := categorizeFiles(wtStatus)
+
+	assert.Equal(t, models.ChangeCounts{
+		Staged:     3,
+		Modified:   2,
+		Deleted:    1,
+		Renamed:    1,
+		Untracked:  2,
+		Conflicted: 1,
+	}, categories.counts())
+	assert.Equal(t, []string{"notes.txt", "scratch.txt"}, categories.untracked)
 }
 
 // T039: Test Extract() handling bare repositories.
@@ -360,6 +650,34 @@ func TestExtractBatch_AggregateErrors(t *testing.T) {
 	assert.NotEmpty(t, invalidStatus.Error)
 }
 
+// Test ExtractBatch reports each result through OnResult as it is collected.
+func TestExtractBatch_OnResultCallback(t *testing.T) {
+	repos := make(map[string]*models.Repository)
+	for i := range 3 {
+		repoPath := createTestRepoWithState(t, "basic")
+		repos[repoPath] = &models.Repository{
+			Path: repoPath,
+			Name: fmt.Sprintf("repo%d", i),
+		}
+	}
+
+	reported := make(map[string]*models.GitStatus)
+	opts := &ExtractOptions{
+		Timeout:        10 * time.Second,
+		MaxConcurrency: 2,
+		OnResult: func(path string, status *models.GitStatus) {
+			reported[path] = status
+		},
+	}
+
+	batchResult := ExtractBatch(context.Background(), repos, opts)
+
+	assert.Len(t, reported, 3)
+	for path, status := range batchResult.Statuses {
+		assert.Same(t, status, reported[path], "callback should receive the collected status for %s", path)
+	}
+}
+
 // Additional test: Extract with custom timeout option.
 func TestExtract_WithTimeoutOption(t *testing.T) {
 	repoPath := createTestRepoWithState(t, "basic")
@@ -0,0 +1,101 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// extractSubmodules records the state of every submodule listed in .gitmodules.
+// The expected commit is the gitlink recorded in the parent's index; the checkout is opened
+// directly rather than through go-git's Submodule.Repository, which initializes missing
+// submodules. Checked-out submodules get their own status, extracted recursively.
+func extractSubmodules(
+	ctx context.Context,
+	repo *git.Repository,
+	repoPath string,
+	status *models.GitStatus,
+	opts *ExtractOptions,
+	ignorePatterns []gitignore.Pattern,
+) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	submodules, err := worktree.Submodules()
+	if err != nil || len(submodules) == 0 {
+		return nil //nolint:nilerr // A missing or malformed .gitmodules means there is nothing to report
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return fmt.Errorf("failed to read index: %w", err)
+	}
+
+	result := make([]models.SubmoduleStatus, 0, len(submodules))
+	for _, submodule := range submodules {
+		if err := checkContext(ctx); err != nil {
+			return err
+		}
+
+		cfg := submodule.Config()
+		subStatus := models.SubmoduleStatus{
+			Name:  cfg.Name,
+			Path:  path.Clean(filepath.ToSlash(cfg.Path)),
+			State: models.SubmoduleUninitialized,
+		}
+
+		if entry, err := idx.Entry(subStatus.Path); err == nil {
+			subStatus.Expected = entry.Hash.String()
+		}
+
+		checkoutPath := filepath.Join(repoPath, filepath.FromSlash(subStatus.Path))
+		if subRepo, err := openRepository(checkoutPath); err == nil {
+			if head, err := subRepo.Head(); err == nil {
+				subStatus.Current = head.Hash().String()
+			}
+		}
+
+		// A checkout without a resolvable HEAD is treated as not initialized
+		if subStatus.Current != "" {
+			if child, err := extractGitStatus(ctx, checkoutPath, opts, ignorePatterns); err == nil {
+				subStatus.Status = child
+			}
+			subStatus.State = submoduleState(subStatus.Expected, subStatus.Current, subStatus.Status)
+		}
+
+		result = append(result, subStatus)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	status.Submodules = result
+
+	return nil
+}
+
+// submoduleState classifies a checked-out submodule. Uncommitted changes take precedence
+// over a commit mismatch, which takes precedence over being in sync.
+func submoduleState(expected, current string, child *models.GitStatus) models.SubmoduleState {
+	if child != nil && (child.HasChanges || child.SubmodulesNeedingAttention() > 0) {
+		return models.SubmoduleDirty
+	}
+	if expected != current {
+		return models.SubmoduleDifferentCommit
+	}
+
+	return models.SubmoduleInSync
+}
@@ -0,0 +1,135 @@
+package gitstatus
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitTestFile writes a file in the repository at repoPath and commits it.
+func commitTestFile(t *testing.T, repoPath, name, content string) plumbing.Hash {
+	t.Helper()
+
+	repo, err := git.PlainOpen(repoPath)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o600))
+	_, err = worktree.Add(name)
+	require.NoError(t, err)
+
+	hash, err := worktree.Commit("Add "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return hash
+}
+
+// createTestRepoWithSubmodules creates a repository with two submodules registered in
+// .gitmodules: "libs/core", checked out at the recorded commit, and "vendor/tool", which
+// is recorded but not checked out. It returns the parent path.
+func createTestRepoWithSubmodules(t *testing.T) string {
+	t.Helper()
+
+	parentPath := t.TempDir()
+	parent, err := git.PlainInit(parentPath, false)
+	require.NoError(t, err)
+
+	corePath := filepath.Join(parentPath, "libs", "core")
+	_, err = git.PlainInit(corePath, false)
+	require.NoError(t, err)
+	coreHead := commitTestFile(t, corePath, "core.go", "package core\n")
+
+	// An empty directory is what git leaves for a submodule that is not initialized
+	require.NoError(t, os.MkdirAll(filepath.Join(parentPath, "vendor", "tool"), 0o750))
+
+	gitmodules := "[submodule \"core\"]\n\tpath = libs/core\n\turl = https://example.com/core.git\n" +
+		"[submodule \"tool\"]\n\tpath = vendor/tool\n\turl = https://example.com/tool.git\n"
+	commitTestFile(t, parentPath, ".gitmodules", gitmodules)
+
+	// Record the gitlinks in the index, as git submodule add does
+	idx, err := parent.Storer.Index()
+	require.NoError(t, err)
+	idx.Entries = append(idx.Entries,
+		&index.Entry{Name: "libs/core", Mode: filemode.Submodule, Hash: coreHead},
+		&index.Entry{Name: "vendor/tool", Mode: filemode.Submodule, Hash: plumbing.NewHash("1111111111111111111111111111111111111111")},
+	)
+	require.NoError(t, parent.Storer.SetIndex(idx))
+
+	return parentPath
+}
+
+// Test Extract() reports the state of each submodule, sorted by path.
+func TestExtract_ReportsSubmodules(t *testing.T) {
+	parentPath := createTestRepoWithSubmodules(t)
+
+	status, err := Extract(context.Background(), parentPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	require.Len(t, status.Submodules, 2)
+
+	core := status.Submodules[0]
+	assert.Equal(t, "core", core.Name)
+	assert.Equal(t, "libs/core", core.Path)
+	assert.Equal(t, models.SubmoduleInSync, core.State)
+	assert.Equal(t, core.Expected, core.Current)
+	require.NotNil(t, core.Status)
+	assert.Equal(t, "master", core.Status.Branch)
+
+	tool := status.Submodules[1]
+	assert.Equal(t, "vendor/tool", tool.Path)
+	assert.Equal(t, models.SubmoduleUninitialized, tool.State)
+	assert.Empty(t, tool.Current)
+	assert.Nil(t, tool.Status)
+
+	assert.Equal(t, 1, status.SubmodulesNeedingAttention())
+}
+
+// Test Extract() detects a submodule moved to another commit or with uncommitted changes.
+func TestExtract_DetectsChangedSubmodules(t *testing.T) {
+	t.Run("different commit", func(t *testing.T) {
+		parentPath := createTestRepoWithSubmodules(t)
+		commitTestFile(t, filepath.Join(parentPath, "libs", "core"), "extra.go", "package core\n")
+
+		status, err := Extract(context.Background(), parentPath, nil, []gitignore.Pattern{})
+		require.NoError(t, err)
+		require.Len(t, status.Submodules, 2)
+		assert.Equal(t, models.SubmoduleDifferentCommit, status.Submodules[0].State)
+		assert.NotEqual(t, status.Submodules[0].Expected, status.Submodules[0].Current)
+	})
+
+	t.Run("uncommitted changes", func(t *testing.T) {
+		parentPath := createTestRepoWithSubmodules(t)
+		commitTestFile(t, filepath.Join(parentPath, "libs", "core"), "extra.go", "package core\n")
+		err := os.WriteFile(filepath.Join(parentPath, "libs", "core", "wip.go"), []byte("package core\n"), 0o600)
+		require.NoError(t, err)
+
+		status, err := Extract(context.Background(), parentPath, nil, []gitignore.Pattern{})
+		require.NoError(t, err)
+		require.Len(t, status.Submodules, 2)
+		assert.Equal(t, models.SubmoduleDirty, status.Submodules[0].State, "changes take precedence over the commit")
+		assert.Equal(t, 1, status.Submodules[0].Status.Changes.Untracked)
+	})
+}
+
+// Test Extract() leaves Submodules empty for repositories without .gitmodules.
+func TestExtract_NoSubmodules(t *testing.T) {
+	repoPath := createTestRepoWithState(t, "with-changes")
+
+	status, err := Extract(context.Background(), repoPath, nil, []gitignore.Pattern{})
+	require.NoError(t, err)
+	assert.Empty(t, status.Submodules)
+}
@@ -2,11 +2,13 @@ package models
 
 // FetchStats tracks fetch operation statistics.
 type FetchStats struct {
-	TotalAttempted int      // Repos where fetch was attempted
-	Successful     int      // Successful fetches (including already up-to-date)
-	Skipped        int      // Repos skipped (no origin remote, bare repos, etc.)
-	Failed         int      // Repos where fetch failed after retries
-	FailedRepos    []string // Paths of repos that failed to fetch
+	TotalAttempted   int      // Repos where fetch was attempted
+	Successful       int      // Successful fetches (including already up-to-date)
+	Skipped          int      // Repos skipped (no origin remote, bare repos, etc.)
+	Fresh            int      // Repos not fetched because they were fetched within the maximum fetch age
+	Failed           int      // Repos where fetch failed after retries
+	FailedRepos      []string // Paths of repos that failed to fetch
+	UnreachableHosts []string // Hosts whose remaining fetches were skipped after repeated connection failures
 }
 
 // BatchResult represents the result of a batch Git status extraction operation.
@@ -4,34 +4,26 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
-
-	"github.com/fatih/color"
-)
-
-const maxCommitsToCount = 99
-
-// Package-level color functions for status formatting.
-//
-//nolint:gochecknoglobals // These are immutable color functions, safe for concurrent use.
-var (
-	grayColor   = color.New(color.FgHiBlack, color.Bold).SprintFunc()
-	yellowColor = color.New(color.FgYellow, color.Bold).SprintFunc()
-	greenColor  = color.New(color.FgGreen, color.Bold).SprintFunc()
-	redColor    = color.New(color.FgRed, color.Bold).SprintFunc()
 )
 
 // Repository represents a Git repository discovered during directory scanning.
 type Repository struct {
-	Path       string     // Absolute file system path to the repository directory
-	Name       string     // Base name of the repository directory
-	IsBare     bool       // Whether the repository is a bare repository
-	IsSymlink  bool       // Whether the repository was reached via a symbolic link
-	GitStatus  *GitStatus // Current Git status information (nil if error occurred)
-	Error      error      // Error encountered during processing
-	HasTimeout bool       // Whether Git operations timed out
+	Path       string // Absolute file system path to the repository directory
+	Name       string // Base name of the repository directory
+	IsBare     bool   // Whether the repository is a bare repository
+	IsSymlink  bool   // Whether the repository was reached via a symbolic link
+	GitDir     string // Git directory named by a .git file (worktree, submodule, separate git dir), empty if Path/.git
+	CommonDir  string // Git directory shared by all worktrees of the repository, empty if unknown
+	IsWorktree bool   // Whether the directory is a linked worktree created by git worktree add
+	WorktreeOf string // Path of the main repository of a linked worktree, empty otherwise
+	// SubmoduleOf is the path of the parent repository of a submodule tree node, empty otherwise
+	SubmoduleOf    string
+	SubmoduleState SubmoduleState // State of a submodule tree node relative to its parent, empty otherwise
+	GitStatus      *GitStatus     // Current Git status information (nil if error occurred)
+	Error          error          // Error encountered during processing
+	HasTimeout     bool           // Whether Git operations timed out
 }
 
 var (
@@ -59,15 +51,174 @@ func (r *Repository) Validate() error {
 
 // GitStatus represents the Git status information for a repository.
 type GitStatus struct {
-	Branch     string // Current branch name or "DETACHED" if HEAD is detached
-	IsDetached bool   // Whether HEAD is in detached state
-	HasRemote  bool   // Whether repository has a remote configured
-	Ahead      int    // Number of commits ahead of remote
-	Behind     int    // Number of commits behind remote
-	HasStashes bool   // Whether repository has stashed changes
-	HasChanges bool   // Whether repository has uncommitted changes
-	Error      string // Partial error message if some status info couldn't be retrieved
-	FetchError string // Error from fetch operation (separate from status extraction error)
+	Branch            string            // Current branch name or "DETACHED" if HEAD is detached
+	IsDetached        bool              // Whether HEAD is in detached state
+	DefaultBranch     string            // Default branch named by refs/remotes/origin/HEAD, empty if unknown
+	BaseBranch        string            // Remote default branch BaseAhead/BaseBehind refer to (e.g. "origin/main"), empty if not compared
+	BaseAhead         int               // Commits on HEAD that are not in BaseBranch
+	BaseBehind        int               // Commits in BaseBranch that HEAD lacks
+	Operation         string            // In-progress operation (e.g. "REBASING 3/7", "MERGING"), empty if none
+	HasRemote         bool              // Whether repository has a remote configured
+	RemoteHost        string            // Host of the primary remote's URL (e.g. "github.com"), empty if none or local
+	Upstream          string            // Remote tracking branch used for ahead/behind (e.g. "origin/main"), empty if none
+	UpstreamGone      bool              // Whether the configured Upstream no longer exists (deleted on the remote and pruned)
+	Ahead             int               // Number of commits ahead of remote
+	Behind            int               // Number of commits behind remote
+	HasStashes        bool              // Whether repository has stashed changes
+	StashCount        int               // Number of stash entries (0 if none or unknown)
+	Stashes           []StashEntry      // Stash entries, newest (stash@{0}) first
+	Submodules        []SubmoduleStatus // Submodules registered in .gitmodules, sorted by path
+	Branches          []BranchStatus    // Local branches sorted by name, nil unless requested with ExtractOptions.Branches
+	HasChanges        bool              // Whether repository has uncommitted changes
+	Changes           ChangeCounts      // Number of changed files by category (all zero if unknown)
+	LastCommitHash    string            // Hash of the HEAD commit, empty if unknown
+	LastCommitSubject string            // First line of the HEAD commit message
+	LastCommitAuthor  string            // Author name of the HEAD commit
+	LastCommitDate    time.Time         // Committer date of the HEAD commit (zero if unknown)
+	WorktreeModified  time.Time         // Latest modification time of changed worktree files or the index (zero if unknown)
+	Error             string            // Partial error message if some status info couldn't be retrieved
+	FetchError        string            // Error from fetch operation (separate from status extraction error)
+}
+
+// SyncState describes HEAD's branch relative to its upstream.
+type SyncState string
+
+// Sync states reported by GitStatus.SyncState.
+const (
+	SyncNone        SyncState = ""             // No upstream to compare with
+	SyncInSync      SyncState = "in-sync"      // At the same commit as the upstream
+	SyncAhead       SyncState = "ahead"        // Only has commits the upstream lacks
+	SyncFastForward SyncState = "fast-forward" // Strictly behind, so it can be fast-forwarded
+	SyncDiverged    SyncState = "diverged"     // Both ahead and behind, so it needs a merge or rebase
+	SyncGone        SyncState = "gone"         // The configured upstream no longer exists
+)
+
+// SyncState returns the state of HEAD's branch relative to its upstream.
+func (g *GitStatus) SyncState() SyncState {
+	switch {
+	case g.UpstreamGone:
+		return SyncGone
+	case g.Upstream == "":
+		return SyncNone
+	case g.Ahead > 0 && g.Behind > 0:
+		return SyncDiverged
+	case g.Behind > 0:
+		return SyncFastForward
+	case g.Ahead > 0:
+		return SyncAhead
+	default:
+		return SyncInSync
+	}
+}
+
+// ChangeCounts holds the number of changed files in a worktree by category.
+// A file that is staged and then modified again counts as both staged and modified.
+type ChangeCounts struct {
+	Staged     int // Files with staged additions, modifications or deletions
+	Modified   int // Files modified in the worktree but not staged
+	Deleted    int // Files deleted in the worktree but not staged
+	Renamed    int // Files with a staged rename
+	Untracked  int // Files not tracked by Git
+	Conflicted int // Files with unresolved merge conflicts
+}
+
+// Total returns the number of changes across all categories.
+func (c ChangeCounts) Total() int {
+	return c.Staged + c.Modified + c.Deleted + c.Renamed + c.Untracked + c.Conflicted
+}
+
+// OnlyUntracked reports whether untracked files are the only changes.
+func (c ChangeCounts) OnlyUntracked() bool {
+	return c.Untracked > 0 && c.Total() == c.Untracked
+}
+
+// StashEntry describes a single entry of the stash list.
+type StashEntry struct {
+	Index   int       // Position in the stash list (N in stash@{N})
+	Branch  string    // Branch the stash was created on, empty if unknown
+	Message string    // Stash message (e.g. "WIP on main: 1a2b3c4 Add parser")
+	Date    time.Time // When the stash was created
+}
+
+// SubmoduleState describes a submodule checkout relative to the commit its parent records.
+type SubmoduleState string
+
+// Submodule states, from least to most specific problem.
+const (
+	SubmoduleInSync          SubmoduleState = "in-sync"          // Checked out at the recorded commit without changes
+	SubmoduleUninitialized   SubmoduleState = "uninitialized"    // Registered in .gitmodules but not checked out
+	SubmoduleDifferentCommit SubmoduleState = "different-commit" // Checked out at a commit other than the recorded one
+	SubmoduleDirty           SubmoduleState = "dirty"            // Has uncommitted changes of its own
+)
+
+// SubmoduleStatus is the state of one submodule of a repository.
+type SubmoduleStatus struct {
+	Name     string         // Submodule name from .gitmodules
+	Path     string         // Path of the checkout relative to the parent repository, "/"-separated
+	State    SubmoduleState // Dirty takes precedence over DifferentCommit
+	Expected string         // Commit recorded in the parent's index, empty if none
+	Current  string         // Commit checked out in the submodule, empty if uninitialized
+	Status   *GitStatus     // Git status of the checkout, nil if uninitialized
+}
+
+// NeedsAttention reports whether the submodule is not checked out cleanly at the recorded commit.
+func (s SubmoduleStatus) NeedsAttention() bool {
+	return s.State != SubmoduleInSync
+}
+
+// SubmodulesNeedingAttention returns the number of submodules that are not in sync.
+func (g *GitStatus) SubmodulesNeedingAttention() int {
+	count := 0
+	for _, sub := range g.Submodules {
+		if sub.NeedsAttention() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// BranchStatus describes one local branch of a repository.
+type BranchStatus struct {
+	Name           string    // Short branch name (e.g. "feature/login")
+	Upstream       string    // Remote tracking branch (e.g. "origin/feature/login"), empty if none
+	UpstreamGone   bool      // Whether the configured Upstream no longer exists
+	Unpushed       int       // Commits on the branch that are on no remote-tracking branch
+	LastCommitDate time.Time // Committer date of the branch tip
+	IsHead         bool      // Whether the branch is checked out
+}
+
+// Indicators returns the branch's upstream, marked when it is gone, or a no-remote marker when
+// it has none, followed by the number of unpushed commits.
+func (b BranchStatus) Indicators() []Indicator {
+	var parts []Indicator
+	symbols := CurrentTheme().Symbols
+
+	if b.Upstream != "" {
+		parts = append(parts, Indicator{KindUpstream, symbols.Upstream + b.Upstream, ColorGray})
+		if b.UpstreamGone {
+			parts = append(parts, Indicator{KindUpstreamGone, symbols.Gone, ColorYellow})
+		}
+	} else {
+		parts = append(parts, Indicator{KindNoRemote, symbols.NoRemote, ColorYellow})
+	}
+	if b.Unpushed > 0 {
+		parts = append(parts, Indicator{KindAhead, fmt.Sprintf("%s%d", symbols.Ahead, b.Unpushed), ColorGreen})
+	}
+
+	return parts
+}
+
+// UnpushedBranches returns the number of local branches with commits on no remote-tracking branch.
+func (g *GitStatus) UnpushedBranches() int {
+	count := 0
+	for _, branch := range g.Branches {
+		if branch.Unpushed > 0 {
+			count++
+		}
+	}
+
+	return count
 }
 
 var errGitStatusValidation = errors.New("git status validation error")
@@ -92,108 +243,295 @@ func (g *GitStatus) Validate() error {
 
 // IsStandardStatus returns true if the repository is in a standard state.
 func (g *GitStatus) IsStandardStatus() bool {
-	// Standard state: on main/master, in sync with remote, no stashes, no changes, no errors
-	return (g.Branch == "main" || g.Branch == "master") && //nolint:goconst // "main" and "master" are domain literals
+	// Standard state: on a trunk branch, in sync with remote, no stashes, no changes, no operation in progress,
+	// all submodules at their recorded commits, no unpushed commits on other branches, no errors
+	return g.IsTrunk() &&
 		g.HasRemote &&
 		g.Ahead == 0 &&
 		g.Behind == 0 &&
+		!g.UpstreamGone &&
 		!g.HasStashes &&
 		!g.HasChanges &&
+		g.Operation == "" &&
+		g.SubmodulesNeedingAttention() == 0 &&
+		g.UnpushedBranches() == 0 &&
 		g.Error == "" &&
 		g.FetchError == ""
 }
 
-// Format returns the formatted Git status string for display with colorization.
-func (g *GitStatus) Format() string {
-	// Examples (with colors disabled):
-	//   - [[ main ]] - On main, in sync with remote, no changes (gray brackets)
-	//   - [[ main | ↑2 ↓1 ]] - 2 commits ahead, 1 behind (yellow brackets)
-	//   - [[ develop | $ * ]] - Has stashes and uncommitted changes (yellow brackets)
-	//   - [[ DETACHED ]] - Detached HEAD state (yellow brackets)
-	//   - [[ main | ○ ]] - No remote configured (yellow brackets)
-	//   - [[ main | error ]] - Partial error retrieving status (yellow brackets)
-	//   - [[ main | fetch-err ]] - Fetch from origin failed (yellow brackets)
-	//   - [[ N/A | error ]] - Error retrieving status (N/A and error are red, yellow brackets)
-	var parts []string
-
-	// Branch: gray for main/master, red for N/A, yellow otherwise
-	switch g.Branch {
-	case "main", "master":
-		parts = append(parts, grayColor(g.Branch))
-	case "N/A":
-		parts = append(parts, redColor(g.Branch))
+// IndicatorColor is the semantic color of a status indicator.
+type IndicatorColor int
+
+// Indicator colors used by GitStatus.Indicators.
+const (
+	ColorGray IndicatorColor = iota
+	ColorYellow
+	ColorGreen
+	ColorRed
+)
+
+// String returns the lowercase color name.
+func (c IndicatorColor) String() string {
+	switch c {
+	case ColorYellow:
+		return "yellow"
+	case ColorGreen:
+		return "green"
+	case ColorRed:
+		return "red"
+	default:
+		return "gray"
+	}
+}
+
+// IndicatorKind identifies what a status indicator describes.
+type IndicatorKind string
+
+// Indicator kinds produced by GitStatus.Indicators.
+const (
+	KindBranch       IndicatorKind = "branch"
+	KindUpstream     IndicatorKind = "upstream"
+	KindOperation    IndicatorKind = "operation"
+	KindUpstreamGone IndicatorKind = "upstream-gone"
+	KindDiverged     IndicatorKind = "diverged"
+	KindFastForward  IndicatorKind = "fast-forward"
+	KindAhead        IndicatorKind = "ahead"
+	KindBehind       IndicatorKind = "behind"
+	KindBaseAhead    IndicatorKind = "base-ahead"
+	KindBaseBehind   IndicatorKind = "base-behind"
+	KindNoRemote     IndicatorKind = "no-remote"
+	KindStashes      IndicatorKind = "stashes"
+	KindSubmodules   IndicatorKind = "submodules"
+	KindChanges      IndicatorKind = "changes"
+	KindStaged       IndicatorKind = "staged"
+	KindModified     IndicatorKind = "modified"
+	KindDeleted      IndicatorKind = "deleted"
+	KindRenamed      IndicatorKind = "renamed"
+	KindUntracked    IndicatorKind = "untracked"
+	KindConflicted   IndicatorKind = "conflicted"
+	KindError        IndicatorKind = "error"
+	KindFetchError   IndicatorKind = "fetch-error"
+)
+
+// Indicator is a single piece of Git status information, such as the branch name
+// or the ahead count, together with the color it is displayed in.
+type Indicator struct {
+	Kind  IndicatorKind
+	Text  string
+	Color IndicatorColor
+}
+
+// Indicators returns the status indicators in display order. The first indicator is
+// always the branch name, optionally followed by the upstream; the remaining ones are
+// the status markers shown after them.
+func (g *GitStatus) Indicators() []Indicator {
+	var parts []Indicator
+	symbols := CurrentTheme().Symbols
+
+	// Branch: gray for trunk branches, red for N/A, yellow otherwise
+	switch {
+	case g.IsTrunk():
+		parts = append(parts, Indicator{KindBranch, g.Branch, ColorGray})
+	case g.Branch == "N/A":
+		parts = append(parts, Indicator{KindBranch, g.Branch, ColorRed})
 	default:
-		parts = append(parts, yellowColor(g.Branch))
+		parts = append(parts, Indicator{KindBranch, g.Branch, ColorYellow})
+	}
+
+	// Upstream: gray, only when it differs from the usual origin/<branch>
+	if g.Upstream != "" && g.Upstream != "origin/"+g.Branch {
+		parts = append(parts, Indicator{KindUpstream, symbols.Upstream + g.Upstream, ColorGray})
+	}
+
+	// In-progress operation: red, first of the status markers
+	if g.Operation != "" {
+		parts = append(parts, Indicator{KindOperation, g.Operation, ColorRed})
 	}
 
-	// Ahead/Behind: green/red, or gray no-remote indicator
+	// Ahead/Behind: green/red, or yellow no-remote indicator
 	if g.HasRemote {
-		parts = append(parts, g.formatAheadBehind()...)
+		parts = append(parts, g.aheadBehindIndicators()...)
+		parts = append(parts, g.baseIndicators()...)
 	} else if g.Error == "" {
 		// Only show no-remote indicator if there's no error
-		parts = append(parts, yellowColor("○"))
+		parts = append(parts, Indicator{KindNoRemote, symbols.NoRemote, ColorYellow})
 	}
 
-	// Stashes: red
+	// Stashes: red, with the number of entries when known, such as "stashed:3" or just "stashed"
 	if g.HasStashes {
-		parts = append(parts, redColor("$"))
+		text := strings.TrimSuffix(symbols.Stashes, ":")
+		if g.StashCount > 0 {
+			text = fmt.Sprintf("%s%d", symbols.Stashes, g.StashCount)
+		}
+		parts = append(parts, Indicator{KindStashes, text, ColorRed})
+	}
+
+	// Submodules not in sync with the recorded commit: yellow count
+	if count := g.SubmodulesNeedingAttention(); count > 0 {
+		parts = append(parts, Indicator{KindSubmodules, fmt.Sprintf("%s%d", symbols.Submodules, count), ColorYellow})
 	}
 
-	// Uncommitted changes: red
+	// Uncommitted changes: counts per category, or a single marker if counts are unknown
 	if g.HasChanges {
-		parts = append(parts, redColor("*"))
+		if changes := g.changeIndicators(symbols); len(changes) > 0 {
+			parts = append(parts, changes...)
+		} else {
+			parts = append(parts, Indicator{KindChanges, symbols.Changes, ColorRed})
+		}
 	}
 
 	// Error indicator: red (added as status indicator)
 	if g.Error != "" {
-		parts = append(parts, redColor("error"))
+		parts = append(parts, Indicator{KindError, symbols.Error, ColorRed})
 	}
 
 	// Fetch error indicator: red (separate from status extraction error)
 	if g.FetchError != "" {
-		parts = append(parts, redColor("fetch-err"))
+		parts = append(parts, Indicator{KindFetchError, symbols.FetchError, ColorRed})
 	}
 
-	// Build result with brackets (yellow for non-standard status, gray for standard) and separator
-	bracketColor := grayColor
-	if !g.IsStandardStatus() {
-		bracketColor = yellowColor
+	return parts
+}
+
+// aheadBehindIndicators returns ahead/behind indicators. A gone upstream replaces the counts,
+// a diverged branch is marked before them, and a branch that can be fast-forwarded is marked
+// before its behind count, which is yellow rather than red.
+func (g *GitStatus) aheadBehindIndicators() []Indicator {
+	var parts []Indicator
+	symbols := CurrentTheme().Symbols
+
+	if g.UpstreamGone {
+		return append(parts, Indicator{KindUpstreamGone, symbols.Gone, ColorYellow})
 	}
 
-	var result string
-	if len(parts) == 1 {
-		// Only branch, no separator needed
-		result = bracketColor("[[") + " " + parts[0] + " " + bracketColor("]]")
-	} else {
-		// Branch + status indicators, use separator
-		separator := " " + grayColor("|") + " "
-		statusParts := strings.Join(parts[1:], " ")
-		result = bracketColor("[[") + " " + parts[0] + separator + statusParts + " " + bracketColor("]]")
+	diverged := g.Ahead > 0 && g.Behind > 0
+	if diverged && symbols.Diverged != "" {
+		parts = append(parts, Indicator{KindDiverged, symbols.Diverged, ColorRed})
+	}
+	if g.Ahead > 0 {
+		parts = append(parts, Indicator{KindAhead, fmt.Sprintf("%s%d", symbols.Ahead, g.Ahead), ColorGreen})
+	}
+	if g.SyncState() == SyncFastForward && symbols.FastForward != "" {
+		parts = append(parts, Indicator{KindFastForward, symbols.FastForward, ColorYellow})
+	}
+	if g.Behind > 0 {
+		behindColor := ColorYellow
+		if diverged {
+			behindColor = ColorRed
+		}
+		parts = append(parts, Indicator{KindBehind, fmt.Sprintf("%s%d", symbols.Behind, g.Behind), behindColor})
 	}
 
-	return result
+	return parts
 }
 
-// formatAheadBehind returns formatted ahead/behind indicators.
-func (g *GitStatus) formatAheadBehind() []string {
-	var parts []string
+// baseIndicators returns the divergence from the remote default branch, such as "⇡3 ⇣12".
+// Commits behind the default branch are yellow, since they show how stale the branch is.
+func (g *GitStatus) baseIndicators() []Indicator {
+	var parts []Indicator
+	symbols := CurrentTheme().Symbols
 
-	if g.Ahead > 0 && g.Ahead <= maxCommitsToCount {
-		parts = append(parts, greenColor(fmt.Sprintf("↑%d", g.Ahead)))
+	if g.BaseBranch == "" {
+		return parts
 	}
-	if g.Ahead > maxCommitsToCount {
-		parts = append(parts, greenColor(fmt.Sprintf("↑%d+", maxCommitsToCount)))
+	if g.BaseAhead > 0 {
+		parts = append(parts, Indicator{KindBaseAhead, fmt.Sprintf("%s%d", symbols.BaseAhead, g.BaseAhead), ColorGray})
 	}
-	if g.Behind > 0 && g.Behind <= maxCommitsToCount {
-		parts = append(parts, redColor(fmt.Sprintf("↓%d", g.Behind)))
+	if g.BaseBehind > 0 {
+		parts = append(parts, Indicator{KindBaseBehind, fmt.Sprintf("%s%d", symbols.BaseBehind, g.BaseBehind), ColorYellow})
 	}
-	if g.Behind > maxCommitsToCount {
-		parts = append(parts, redColor(fmt.Sprintf("↓%d+", maxCommitsToCount)))
+
+	return parts
+}
+
+// changeIndicators returns one indicator per non-empty change category, such as "+3 ~2 ?5 !1".
+func (g *GitStatus) changeIndicators(symbols Symbols) []Indicator {
+	categories := []struct {
+		kind   IndicatorKind
+		symbol string
+		count  int
+		color  IndicatorColor
+	}{
+		{KindStaged, symbols.Staged, g.Changes.Staged, ColorGreen},
+		{KindModified, symbols.Modified, g.Changes.Modified, ColorRed},
+		{KindDeleted, symbols.Deleted, g.Changes.Deleted, ColorRed},
+		{KindRenamed, symbols.Renamed, g.Changes.Renamed, ColorGreen},
+		{KindUntracked, symbols.Untracked, g.Changes.Untracked, ColorYellow},
+		{KindConflicted, symbols.Conflicted, g.Changes.Conflicted, ColorRed},
+	}
+
+	var parts []Indicator
+	for _, c := range categories {
+		if c.count > 0 {
+			parts = append(parts, Indicator{c.kind, fmt.Sprintf("%s%d", c.symbol, c.count), c.color})
+		}
 	}
 
 	return parts
 }
 
+// Colored returns the indicator text wrapped in its terminal color.
+// No escape codes are added when color output is disabled.
+func (i Indicator) Colored() string {
+	return ColorText(i.Color, i.Text)
+}
+
+// ColorText wraps text in the active theme's terminal color for c.
+// No escape codes are added when color output is disabled.
+func ColorText(c IndicatorColor, text string) string {
+	return themeColor(c)(text)
+}
+
+// Format returns the formatted Git status string for display with colorization.
+func (g *GitStatus) Format() string {
+	// Examples (with colors disabled):
+	//   - [[ main ]] - On main, in sync with remote, no changes (gray brackets)
+	//   - [[ main | ↑2 ↓1 ]] - 2 commits ahead, 1 behind (yellow brackets)
+	//   - [[ feature →upstream/main | ↑2 ]] - Tracks a branch other than origin/feature
+	//   - [[ develop | $2 ~1 ]] - Has 2 stashes and a modified file (yellow brackets)
+	//   - [[ DETACHED ]] - Detached HEAD state (yellow brackets)
+	//   - [[ DETACHED | REBASING 3/7 !1 ]] - Rebase stopped at step 3 of 7 with a conflict (yellow brackets)
+	//   - [[ main | ○ ]] - No remote configured (yellow brackets)
+	//   - [[ main | error ]] - Partial error retrieving status (yellow brackets)
+	//   - [[ main | fetch-err ]] - Fetch from origin failed (yellow brackets)
+	//   - [[ N/A | error ]] - Error retrieving status (N/A and error are red, yellow brackets)
+	// The branch and upstream form the head; everything else goes after the separator
+	var head, parts []string
+	for _, ind := range g.Indicators() {
+		if ind.Kind == KindBranch || ind.Kind == KindUpstream {
+			head = append(head, ind.Colored())
+		} else {
+			parts = append(parts, ind.Colored())
+		}
+	}
+
+	// Build result with brackets (yellow for non-standard status, gray for standard) and separator;
+	// symbols the theme leaves empty are omitted
+	bracketColor := ColorGray
+	if !g.IsStandardStatus() {
+		bracketColor = ColorYellow
+	}
+
+	symbols := CurrentTheme().Symbols
+	pieces := make([]string, 0, len(head)+len(parts))
+	if symbols.Open != "" {
+		pieces = append(pieces, ColorText(bracketColor, symbols.Open))
+	}
+	pieces = append(pieces, head...)
+	if len(parts) > 0 {
+		// Branch + status indicators, use separator
+		if symbols.Separator != "" {
+			pieces = append(pieces, ColorText(ColorGray, symbols.Separator))
+		}
+		pieces = append(pieces, parts...)
+	}
+	if symbols.Close != "" {
+		pieces = append(pieces, ColorText(bracketColor, symbols.Close))
+	}
+
+	return strings.Join(pieces, " ")
+}
+
 // TreeNode represents a node in the hierarchical tree structure.
 type TreeNode struct {
 	Repository   *Repository // The repository at this tree node
@@ -201,6 +539,8 @@ type TreeNode struct {
 	IsLast       bool        // Whether this is the last child of its parent
 	Children     []*TreeNode // Child nodes (nested repositories)
 	RelativePath string      // Path relative to scan root
+	IsDirectory  bool        // Whether this node is a plain directory (scan root or intermediate) rather than a repository
+	Label        string      // Display name overriding Repository.Name (e.g. a collapsed "src/github.com/acme" chain)
 }
 
 var errTreeNodeValidation = errors.New("tree node validation error")
@@ -220,22 +560,25 @@ func (t *TreeNode) Validate() error {
 	return nil
 }
 
+// Name returns the display name of the node: Label if set, otherwise the repository name.
+func (t *TreeNode) Name() string {
+	if t.Label != "" {
+		return t.Label
+	}
+
+	return t.Repository.Name
+}
+
 // AddChild adds a child node to this tree node and sets the child's depth.
 func (t *TreeNode) AddChild(child *TreeNode) {
 	child.Depth = t.Depth + 1
 	t.Children = append(t.Children, child)
 }
 
-// SortChildren sorts the children alphabetically by repository name
+// SortChildren sorts the children alphabetically by display name
 // and updates the IsLast flag for the last child.
 func (t *TreeNode) SortChildren() {
-	sort.Slice(t.Children, func(i, j int) bool {
-		return t.Children[i].Repository.Name < t.Children[j].Repository.Name
-	})
-	// Update IsLast flags
-	for i, child := range t.Children {
-		child.IsLast = (i == len(t.Children)-1)
-	}
+	t.SortChildrenBy(SortByName)
 }
 
 // ScanResult represents the complete result of a directory scan operation.
@@ -504,7 +504,7 @@ func TestGitStatusFormat(t *testing.T) {
 				Ahead:     2,
 				Behind:    1,
 			},
-			expected: "[[ main | ↑2 ↓1 ]]",
+			expected: "[[ main | ⇕ ↑2 ↓1 ]]",
 		},
 		{
 			name: "with stashes",
@@ -561,35 +561,35 @@ func TestGitStatusFormat(t *testing.T) {
 				HasStashes: true,
 				HasChanges: true,
 			},
-			expected: "[[ feature | ↑3 ↓2 $ * ]]",
+			expected: "[[ feature | ⇕ ↑3 ↓2 $ * ]]",
 		},
 		{
-			name: "ahead exceeded limit",
+			name: "large ahead count shown exactly",
 			status: GitStatus{
 				Branch:    "feature",
 				HasRemote: true,
-				Ahead:     maxCommitsToCount + 1,
+				Ahead:     4210,
 			},
-			expected: "[[ feature | ↑99+ ]]",
+			expected: "[[ feature | ↑4210 ]]",
 		},
 		{
-			name: "behind exceeded limit",
+			name: "large behind count shown exactly",
 			status: GitStatus{
 				Branch:    "feature",
 				HasRemote: true,
-				Behind:    maxCommitsToCount + 1,
+				Behind:    1500,
 			},
-			expected: "[[ feature | ↓99+ ]]",
+			expected: "[[ feature | ↓1500 ]]",
 		},
 		{
-			name: "both ahead and behind exceeded",
+			name: "large ahead and behind counts",
 			status: GitStatus{
 				Branch:    "feature",
 				HasRemote: true,
-				Ahead:     maxCommitsToCount + 1,
-				Behind:    maxCommitsToCount + 1,
+				Ahead:     100,
+				Behind:    2500,
 			},
-			expected: "[[ feature | ↑99+ ↓99+ ]]",
+			expected: "[[ feature | ⇕ ↑100 ↓2500 ]]",
 		},
 		{
 			name: "with error",
@@ -629,6 +629,204 @@ func TestGitStatusFormat(t *testing.T) {
 	}
 }
 
+// Test GitStatus.Indicators() returns the branch first followed by colored status markers.
+func TestGitStatusIndicators(t *testing.T) {
+	status := GitStatus{
+		Branch:     "feature",
+		HasRemote:  true,
+		Ahead:      3,
+		Behind:     1,
+		HasStashes: true,
+		FetchError: "fetch failed",
+	}
+
+	expected := []Indicator{
+		{Kind: KindBranch, Text: "feature", Color: ColorYellow},
+		{Kind: KindDiverged, Text: "⇕", Color: ColorRed},
+		{Kind: KindAhead, Text: "↑3", Color: ColorGreen},
+		{Kind: KindBehind, Text: "↓1", Color: ColorRed},
+		{Kind: KindStashes, Text: "$", Color: ColorRed},
+		{Kind: KindFetchError, Text: "fetch-err", Color: ColorRed},
+	}
+	assert.Equal(t, expected, status.Indicators())
+	assert.Equal(t, "gray", (&GitStatus{Branch: "main"}).Indicators()[0].Color.String())
+}
+
+// Test GitStatus.Format() shows per-category change counts instead of a single marker.
+func TestGitStatusFormatChangeCounts(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := GitStatus{
+		Branch:     "main",
+		HasRemote:  true,
+		HasChanges: true,
+		Changes:    ChangeCounts{Staged: 3, Modified: 2, Untracked: 5, Conflicted: 1},
+	}
+	assert.Equal(t, "[[ main | +3 ~2 ?5 !1 ]]", status.Format())
+
+	status.Changes = ChangeCounts{Deleted: 1, Renamed: 2}
+	assert.Equal(t, "[[ main | -1 »2 ]]", status.Format())
+
+	// Without counts, the single changes marker is kept
+	status.Changes = ChangeCounts{}
+	assert.Equal(t, "[[ main | * ]]", status.Format())
+}
+
+// Test Format() shows the upstream only when it is not the usual origin/<branch>.
+func TestGitStatusFormatUpstream(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := GitStatus{Branch: "feature", HasRemote: true, Upstream: "upstream/main", Ahead: 2}
+	assert.Equal(t, "[[ feature →upstream/main | ↑2 ]]", status.Format())
+
+	status.Upstream = "origin/feature"
+	assert.Equal(t, "[[ feature | ↑2 ]]", status.Format())
+
+	status.Upstream = ""
+	assert.Equal(t, "[[ feature | ↑2 ]]", status.Format())
+}
+
+// Test Format() shows an in-progress operation as the first status marker.
+func TestGitStatusFormatOperation(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := GitStatus{
+		Branch: "DETACHED", IsDetached: true, HasRemote: true, Operation: "REBASING 3/7",
+		HasChanges: true, Changes: ChangeCounts{Conflicted: 1},
+	}
+	assert.Equal(t, "[[ DETACHED | REBASING 3/7 !1 ]]", status.Format())
+
+	// A merge on a clean main is still not a standard state
+	status = GitStatus{Branch: "main", HasRemote: true, Operation: "MERGING"}
+	assert.False(t, status.IsStandardStatus())
+	assert.Equal(t, "[[ main | MERGING ]]", status.Format())
+}
+
+// Test Format() counts the submodules that are not at their recorded commit.
+func TestGitStatusFormatSubmodules(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := GitStatus{
+		Branch: "main", HasRemote: true,
+		Submodules: []SubmoduleStatus{
+			{Path: "libs/core", State: SubmoduleInSync},
+			{Path: "libs/ui", State: SubmoduleDifferentCommit},
+			{Path: "vendor/tool", State: SubmoduleUninitialized},
+		},
+	}
+	assert.Equal(t, 2, status.SubmodulesNeedingAttention())
+	assert.False(t, status.IsStandardStatus())
+	assert.Equal(t, "[[ main | §2 ]]", status.Format())
+
+	// Submodules in sync keep the parent standard
+	status.Submodules = status.Submodules[:1]
+	assert.True(t, status.IsStandardStatus())
+	assert.Equal(t, "[[ main ]]", status.Format())
+}
+
+// Test Format() shows the divergence from the default branch after the upstream counts.
+func TestGitStatusFormatBaseAheadBehind(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := GitStatus{
+		Branch: "feature", HasRemote: true, Ahead: 1,
+		BaseBranch: "origin/main", BaseAhead: 3, BaseBehind: 120,
+	}
+	assert.Equal(t, "[[ feature | ↑1 ⇡3 ⇣120 ]]", status.Format())
+
+	indicators := status.Indicators()
+	assert.Equal(t, Indicator{KindBaseBehind, "⇣120", ColorYellow}, indicators[len(indicators)-1])
+
+	// Base counts are not shown without a base branch
+	status = GitStatus{Branch: "feature", HasRemote: true, BaseAhead: 3}
+	assert.Equal(t, "[[ feature ]]", status.Format())
+}
+
+// Test SyncState and the indicators of a gone upstream, a fast-forwardable branch and a diverged branch.
+func TestGitStatusSyncState(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	tests := []struct {
+		name   string
+		status GitStatus
+		state  SyncState
+		format string
+	}{
+		{"no upstream", GitStatus{Branch: "feature", HasRemote: true}, SyncNone, "[[ feature ]]"},
+		{"in sync", GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main"}, SyncInSync, "[[ main ]]"},
+		{"ahead", GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", Ahead: 2}, SyncAhead, "[[ main | ↑2 ]]"},
+		{
+			"fast-forward", GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", Behind: 3},
+			SyncFastForward, "[[ main | ff ↓3 ]]",
+		},
+		{
+			"diverged", GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", Ahead: 1, Behind: 3},
+			SyncDiverged, "[[ main | ⇕ ↑1 ↓3 ]]",
+		},
+		{
+			"gone", GitStatus{Branch: "fix", HasRemote: true, Upstream: "origin/fix", UpstreamGone: true},
+			SyncGone, "[[ fix | gone ]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.state, tt.status.SyncState())
+			assert.Equal(t, tt.format, tt.status.Format())
+		})
+	}
+
+	// The behind count is yellow when the branch can be fast-forwarded and red when it has diverged
+	fastForward := GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", Behind: 3}
+	assert.Equal(t, Indicator{KindFastForward, "ff", ColorYellow}, fastForward.Indicators()[1])
+	assert.Equal(t, Indicator{KindBehind, "↓3", ColorYellow}, fastForward.Indicators()[2])
+	diverged := GitStatus{Branch: "main", HasRemote: true, Ahead: 1, Behind: 3}
+	assert.Equal(t, Indicator{KindBehind, "↓3", ColorRed}, diverged.Indicators()[3])
+
+	gone := GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", UpstreamGone: true}
+	assert.False(t, gone.IsStandardStatus(), "a gone upstream needs attention")
+
+	branch := BranchStatus{Name: "fix", Upstream: "origin/fix", UpstreamGone: true}
+	assert.Equal(t, []Indicator{{KindUpstream, "→origin/fix", ColorGray}, {KindUpstreamGone, "gone", ColorYellow}}, branch.Indicators())
+}
+
+// Test BranchStatus indicators and that unpushed work on any branch makes a repository non-standard.
+func TestBranchStatusIndicators(t *testing.T) {
+	pushed := BranchStatus{Name: "main", Upstream: "origin/main"}
+	assert.Equal(t, []Indicator{{KindUpstream, "→origin/main", ColorGray}}, pushed.Indicators())
+
+	local := BranchStatus{Name: "spike", Unpushed: 3}
+	assert.Equal(t, []Indicator{{KindNoRemote, "○", ColorYellow}, {KindAhead, "↑3", ColorGreen}}, local.Indicators())
+
+	status := GitStatus{Branch: "main", HasRemote: true, Branches: []BranchStatus{pushed}}
+	assert.True(t, status.IsStandardStatus())
+
+	status.Branches = append(status.Branches, local)
+	assert.Equal(t, 1, status.UnpushedBranches())
+	assert.False(t, status.IsStandardStatus())
+}
+
+// Test ChangeCounts helpers.
+func TestChangeCounts(t *testing.T) {
+	assert.Equal(t, 0, ChangeCounts{}.Total())
+	assert.Equal(t, 6, ChangeCounts{Staged: 1, Modified: 2, Untracked: 3}.Total())
+	assert.True(t, ChangeCounts{Untracked: 3}.OnlyUntracked())
+	assert.False(t, ChangeCounts{Untracked: 3, Modified: 1}.OnlyUntracked())
+	assert.False(t, ChangeCounts{}.OnlyUntracked())
+}
+
 // === User Story 1: Distinguish Repository Metadata from Names ===
 
 // T009 [US1]: Verify output uses double brackets [[ ]] instead of [ ].
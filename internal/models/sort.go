@@ -0,0 +1,175 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// SortKey selects the order of sibling nodes in the repository tree.
+type SortKey string
+
+// Sort keys accepted by TreeNode.SortChildrenBy. All keys except SortByName put the
+// highest value first; directories use the highest value found in their subtree.
+const (
+	SortByName     SortKey = "name"     // Alphabetically by display name
+	SortByCommit   SortKey = "commit"   // Most recent HEAD commit first
+	SortByModified SortKey = "modified" // Most recent worktree modification first
+	SortByAhead    SortKey = "ahead"    // Most commits ahead of upstream first
+	SortByBehind   SortKey = "behind"   // Most commits behind upstream first
+	SortBySeverity SortKey = "severity" // Highest attention severity first
+)
+
+// SortKeys returns all supported sort keys in documentation order.
+func SortKeys() []SortKey {
+	return []SortKey{SortByName, SortByCommit, SortByModified, SortByAhead, SortByBehind, SortBySeverity}
+}
+
+// Attention severity weights used by Repository.Severity.
+const (
+	severityError      = 100
+	severityFetchError = 50
+	severityOperation  = 60
+	severityConflicts  = 40
+	severityChanges    = 20
+	severitySubmodules = 10
+	severityUntracked  = 2
+	severityAhead      = 10
+	severityAheadMax   = 9 // Commits ahead counted on top of severityAhead, keeping unpushed work below changes
+	severityBehind     = 5
+	severityGone       = 5
+	severityStashes    = 5
+	severityNoRemote   = 5
+	severityOffTrunk   = 1
+)
+
+// Severity returns a score describing how urgently the repository needs attention.
+// Errors rank highest, followed by half-finished merges, rebases and similar operations,
+// fetch errors, merge conflicts, uncommitted changes and unpushed commits; untracked files
+// alone rank low. More commits ahead rank higher, up to a cap that keeps unpushed work below
+// uncommitted changes. A clean repository on a trunk branch in sync with its remote scores 0.
+// The score is only meant for ordering and its scale may change between releases.
+func (r *Repository) Severity() int {
+	g := r.GitStatus
+	if g == nil {
+		if r.Error != nil {
+			return severityError
+		}
+
+		return 0
+	}
+
+	score := 0
+	if g.Error != "" || r.Error != nil {
+		score += severityError
+	}
+	if g.FetchError != "" {
+		score += severityFetchError
+	}
+	if g.Operation != "" {
+		score += severityOperation
+	}
+	switch {
+	case g.Changes.Conflicted > 0:
+		score += severityConflicts
+	case g.HasChanges && g.Changes.OnlyUntracked():
+		score += severityUntracked
+	case g.HasChanges:
+		score += severityChanges
+	}
+	if g.SubmodulesNeedingAttention() > 0 {
+		score += severitySubmodules
+	}
+	if g.Ahead > 0 {
+		score += severityAhead + min(g.Ahead, severityAheadMax)
+	}
+	if g.Behind > 0 {
+		score += severityBehind
+	}
+	if g.UpstreamGone {
+		score += severityGone
+	}
+	if g.HasStashes {
+		score += severityStashes
+	}
+	if !g.HasRemote {
+		score += severityNoRemote
+	}
+	if !g.IsTrunk() {
+		score += severityOffTrunk
+	}
+
+	return score
+}
+
+// SortChildrenBy sorts the children by key and updates the IsLast flag for the last child.
+// Ties, and every comparison for SortByName, are broken alphabetically by display name.
+// An unknown key sorts by name.
+func (t *TreeNode) SortChildrenBy(key SortKey) {
+	values := make(map[*TreeNode]int64, len(t.Children))
+	if key != SortByName && key != "" {
+		for _, child := range t.Children {
+			values[child] = child.subtreeSortValue(key)
+		}
+	}
+
+	sort.SliceStable(t.Children, func(i, j int) bool {
+		a, b := t.Children[i], t.Children[j]
+		if values[a] != values[b] {
+			return values[a] > values[b]
+		}
+
+		return a.Name() < b.Name()
+	})
+
+	for i, child := range t.Children {
+		child.IsLast = (i == len(t.Children)-1)
+	}
+}
+
+// subtreeSortValue returns the highest sort value of the node and its descendants.
+func (t *TreeNode) subtreeSortValue(key SortKey) int64 {
+	value := int64(0)
+	if !t.IsDirectory && t.Repository != nil {
+		value = t.Repository.sortValue(key)
+	}
+
+	for _, child := range t.Children {
+		value = max(value, child.subtreeSortValue(key))
+	}
+
+	return value
+}
+
+// sortValue returns the repository's own value for key; higher values sort first.
+func (r *Repository) sortValue(key SortKey) int64 {
+	if key == SortBySeverity {
+		return int64(r.Severity())
+	}
+
+	g := r.GitStatus
+	if g == nil {
+		return 0
+	}
+
+	switch key {
+	case SortByCommit:
+		return timeSortValue(g.LastCommitDate)
+	case SortByModified:
+		return timeSortValue(g.WorktreeModified)
+	case SortByAhead:
+		return int64(g.Ahead)
+	case SortByBehind:
+		return int64(g.Behind)
+	default:
+		return 0
+	}
+}
+
+// timeSortValue converts a time to a sort value, mapping the zero time to 0.
+func timeSortValue(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSortTestNode creates a repository tree node for sorting tests.
+func newSortTestNode(name string, status *GitStatus) *TreeNode {
+	return &TreeNode{
+		Repository:   &Repository{Path: "/root/" + name, Name: name, GitStatus: status},
+		RelativePath: name,
+	}
+}
+
+// childNames returns the display names of a node's children in order.
+func childNames(node *TreeNode) []string {
+	names := make([]string, 0, len(node.Children))
+	for _, child := range node.Children {
+		names = append(names, child.Name())
+	}
+
+	return names
+}
+
+// Test Repository.Severity ranks errors above changes and unpushed work.
+func TestRepositorySeverity(t *testing.T) {
+	clean := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true}}
+	ahead1 := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, Ahead: 1}}
+	ahead5 := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, Ahead: 5}}
+	dirty := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, HasChanges: true}}
+	broken := &Repository{Error: ErrFakeError}
+	statusErr := &Repository{GitStatus: &GitStatus{Branch: "N/A", Error: "failed"}}
+
+	assert.Equal(t, 0, clean.Severity())
+	assert.Greater(t, ahead5.Severity(), ahead1.Severity())
+	assert.Greater(t, ahead1.Severity(), clean.Severity())
+	assert.Greater(t, dirty.Severity(), ahead5.Severity())
+	assert.Greater(t, broken.Severity(), dirty.Severity())
+	assert.Greater(t, statusErr.Severity(), dirty.Severity())
+	assert.Equal(t, 0, (&Repository{}).Severity())
+
+	untracked := &Repository{GitStatus: &GitStatus{
+		Branch: "main", HasRemote: true, HasChanges: true, Changes: ChangeCounts{Untracked: 4},
+	}}
+	conflicted := &Repository{GitStatus: &GitStatus{
+		Branch: "main", HasRemote: true, HasChanges: true, Changes: ChangeCounts{Conflicted: 1},
+	}}
+	assert.Less(t, untracked.Severity(), ahead1.Severity(), "untracked scratch files rank below unpushed work")
+	assert.Greater(t, conflicted.Severity(), dirty.Severity())
+
+	rebasing := &Repository{GitStatus: &GitStatus{
+		Branch: "DETACHED", IsDetached: true, HasRemote: true, Operation: "REBASING 1/3",
+		HasChanges: true, Changes: ChangeCounts{Conflicted: 1},
+	}}
+	assert.Greater(t, rebasing.Severity(), conflicted.Severity())
+}
+
+// Test Repository.Severity keeps a repository far ahead of its upstream below errors,
+// stopped operations and uncommitted changes.
+func TestRepositorySeverity_AheadIsBounded(t *testing.T) {
+	ahead500 := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, Ahead: 500}}
+	ahead5 := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, Ahead: 5}}
+	dirty := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, HasChanges: true}}
+	merging := &Repository{GitStatus: &GitStatus{Branch: "main", HasRemote: true, Operation: "MERGING"}}
+	broken := &Repository{Error: ErrFakeError}
+
+	assert.Greater(t, ahead500.Severity(), ahead5.Severity())
+	assert.Less(t, ahead500.Severity(), dirty.Severity())
+	assert.Less(t, ahead500.Severity(), merging.Severity())
+	assert.Less(t, ahead500.Severity(), broken.Severity())
+
+	root := &TreeNode{IsDirectory: true, Children: []*TreeNode{
+		{RelativePath: "ahead", Repository: ahead500},
+		{RelativePath: "broken", Repository: broken},
+	}}
+	root.SortChildrenBy(SortBySeverity)
+	assert.Equal(t, "broken", root.Children[0].RelativePath)
+}
+
+// Test SortChildrenBy orders by each key, highest value first, with ties by name.
+func TestTreeNodeSortChildrenBy(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	newParent := func() *TreeNode {
+		return &TreeNode{
+			Repository:   &Repository{Path: "/root", Name: "."},
+			RelativePath: ".",
+			IsDirectory:  true,
+			Children: []*TreeNode{
+				newSortTestNode("alpha", &GitStatus{
+					Branch: "main", HasRemote: true, Behind: 4,
+					LastCommitDate: now.Add(-48 * time.Hour), WorktreeModified: now.Add(-time.Hour),
+				}),
+				newSortTestNode("bravo", &GitStatus{
+					Branch: "main", HasRemote: true, Ahead: 7,
+					LastCommitDate: now, WorktreeModified: now.Add(-72 * time.Hour),
+				}),
+				newSortTestNode("charlie", &GitStatus{
+					Branch: "main", HasRemote: true, Ahead: 2, HasChanges: true,
+					LastCommitDate: now.Add(-24 * time.Hour),
+				}),
+				newSortTestNode("delta", nil),
+			},
+		}
+	}
+
+	tests := []struct {
+		key  SortKey
+		want []string
+	}{
+		{SortByName, []string{"alpha", "bravo", "charlie", "delta"}},
+		{"", []string{"alpha", "bravo", "charlie", "delta"}},
+		{SortByCommit, []string{"bravo", "charlie", "alpha", "delta"}},
+		{SortByModified, []string{"alpha", "bravo", "charlie", "delta"}},
+		{SortByAhead, []string{"bravo", "charlie", "alpha", "delta"}},
+		{SortByBehind, []string{"alpha", "bravo", "charlie", "delta"}},
+		{SortBySeverity, []string{"charlie", "bravo", "alpha", "delta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			parent := newParent()
+			parent.SortChildrenBy(tt.key)
+
+			assert.Equal(t, tt.want, childNames(parent))
+			assert.True(t, parent.Children[len(parent.Children)-1].IsLast)
+			assert.False(t, parent.Children[0].IsLast)
+		})
+	}
+}
+
+// Test SortChildrenBy ranks directories by the highest value in their subtree.
+func TestTreeNodeSortChildrenBy_DirectoryAggregates(t *testing.T) {
+	dir := &TreeNode{
+		Repository:   &Repository{Path: "/root/dir", Name: "dir"},
+		RelativePath: "dir",
+		IsDirectory:  true,
+		Children: []*TreeNode{
+			newSortTestNode("quiet", &GitStatus{Branch: "main", HasRemote: true}),
+			newSortTestNode("busy", &GitStatus{Branch: "main", HasRemote: true, Ahead: 9}),
+		},
+	}
+	parent := &TreeNode{
+		Repository:   &Repository{Path: "/root", Name: "."},
+		RelativePath: ".",
+		IsDirectory:  true,
+		Children: []*TreeNode{
+			newSortTestNode("alpha", &GitStatus{Branch: "main", HasRemote: true, Ahead: 3}),
+			dir,
+		},
+	}
+
+	parent.SortChildrenBy(SortByAhead)
+
+	require.Len(t, parent.Children, 2)
+	assert.Equal(t, []string{"dir", "alpha"}, childNames(parent))
+}
@@ -0,0 +1,258 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// Built-in theme names.
+const (
+	ThemeDefault = "default"
+	ThemeASCII   = "ascii"
+	ThemeNerd    = "nerd"
+	ThemeWords   = "words"
+)
+
+// Symbols are the glyphs used to render Git status and tree connectors.
+// Count symbols (Ahead, Behind, BaseAhead, BaseBehind, Stashes and the change categories) are followed directly by the number.
+// Empty Open, Close, Separator, Diverged and FastForward values are omitted from the output.
+type Symbols struct {
+	Upstream    string // Prefix of an upstream other than origin/<branch> (e.g. "→")
+	Ahead       string // Prefix of the commits-ahead count (e.g. "↑")
+	Behind      string // Prefix of the commits-behind count (e.g. "↓")
+	BaseAhead   string // Prefix of the commits-ahead-of-the-default-branch count (e.g. "⇡")
+	BaseBehind  string // Prefix of the commits-behind-the-default-branch count (e.g. "⇣")
+	Gone        string // Shown when the configured upstream no longer exists
+	Diverged    string // Shown before the counts of a branch that is both ahead and behind
+	FastForward string // Shown before the behind count of a branch that can be fast-forwarded
+	NoRemote    string // Shown when no remote is configured
+	Stashes     string // Prefix of the stash count (e.g. "$"); a trailing ":" is dropped when the count is unknown
+	Submodules  string // Prefix of the number of submodules needing attention (e.g. "§")
+	Changes     string // Shown when the worktree has uncommitted changes of unknown kind
+	Staged      string // Prefix of the staged file count (e.g. "+")
+	Modified    string // Prefix of the modified file count (e.g. "~")
+	Deleted     string // Prefix of the deleted file count (e.g. "-")
+	Renamed     string // Prefix of the renamed file count (e.g. "»")
+	Untracked   string // Prefix of the untracked file count (e.g. "?")
+	Conflicted  string // Prefix of the conflicted file count (e.g. "!")
+	Error       string // Shown when status extraction partially failed
+	FetchError  string // Shown when fetching from the remote failed
+
+	Open      string // Opening bracket of the status (e.g. "[[")
+	Close     string // Closing bracket of the status (e.g. "]]")
+	Separator string // Separator between the branch and the status indicators (e.g. "|")
+
+	TreeBranch   string // Connector for a non-last child (e.g. "├── ")
+	TreeLast     string // Connector for the last child (e.g. "└── ")
+	TreeVertical string // Prefix continuing a non-last parent (e.g. "│   ")
+	TreeSpace    string // Prefix continuing a last parent (e.g. "    ")
+}
+
+// Palette maps each semantic indicator color to terminal color attributes.
+// An empty attribute list renders the text without escape codes.
+type Palette struct {
+	Gray   []color.Attribute
+	Yellow []color.Attribute
+	Green  []color.Attribute
+	Red    []color.Attribute
+}
+
+// Theme is a named combination of symbols and colors.
+type Theme struct {
+	Name    string
+	Symbols Symbols
+	Palette Palette
+}
+
+// defaultPalette is the palette used by all built-in themes.
+func defaultPalette() Palette {
+	return Palette{
+		Gray:   []color.Attribute{color.FgHiBlack, color.Bold},
+		Yellow: []color.Attribute{color.FgYellow, color.Bold},
+		Green:  []color.Attribute{color.FgGreen, color.Bold},
+		Red:    []color.Attribute{color.FgRed, color.Bold},
+	}
+}
+
+// defaultSymbols are the Unicode symbols of the default theme.
+func defaultSymbols() Symbols {
+	return Symbols{
+		Upstream:     "→",
+		Ahead:        "↑",
+		Behind:       "↓",
+		BaseAhead:    "⇡",
+		BaseBehind:   "⇣",
+		Gone:         "gone",
+		Diverged:     "⇕",
+		FastForward:  "ff",
+		NoRemote:     "○",
+		Stashes:      "$",
+		Submodules:   "§",
+		Changes:      "*",
+		Staged:       "+",
+		Modified:     "~",
+		Deleted:      "-",
+		Renamed:      "»",
+		Untracked:    "?",
+		Conflicted:   "!",
+		Error:        "error",
+		FetchError:   "fetch-err",
+		Open:         "[[",
+		Close:        "]]",
+		Separator:    "|",
+		TreeBranch:   "├── ",
+		TreeLast:     "└── ",
+		TreeVertical: "│   ",
+		TreeSpace:    "    ",
+	}
+}
+
+// BuiltinTheme returns the built-in theme with the given name:
+//   - default: Unicode arrows and box-drawing tree connectors
+//   - ascii: plain ASCII symbols and connectors for limited terminals
+//   - nerd: Nerd Font icons (requires a patched font)
+//   - words: words instead of symbols (e.g. "ahead:2 behind:1 stashed:1 dirty") for screen readers
+func BuiltinTheme(name string) (Theme, bool) {
+	symbols := defaultSymbols()
+
+	switch name {
+	case ThemeDefault:
+	case ThemeASCII:
+		symbols.Upstream = "->"
+		symbols.Ahead = ">"
+		symbols.Behind = "<"
+		symbols.BaseAhead = ">>"
+		symbols.BaseBehind = "<<"
+		symbols.Diverged = "<>"
+		symbols.NoRemote = "o"
+		symbols.Submodules = "S"
+		symbols.Renamed = "r"
+		symbols.TreeBranch = "|-- "
+		symbols.TreeLast = "`-- "
+		symbols.TreeVertical = "|   "
+	case ThemeNerd:
+		symbols.Upstream = "\uf061"    // nf-fa-arrow_right
+		symbols.Ahead = "\uf062"       // nf-fa-arrow_up
+		symbols.Behind = "\uf063"      // nf-fa-arrow_down
+		symbols.BaseAhead = "\uf102"   // nf-fa-angle_double_up
+		symbols.BaseBehind = "\uf103"  // nf-fa-angle_double_down
+		symbols.Gone = "\uf05e"        // nf-fa-ban
+		symbols.Diverged = "\uf126"    // nf-fa-code_fork
+		symbols.FastForward = "\uf050" // nf-fa-fast_forward
+		symbols.NoRemote = "\uf127"    // nf-fa-chain_broken
+		symbols.Stashes = "\uf01c"     // nf-fa-inbox
+		symbols.Submodules = "\uf1e6"  // nf-fa-plug
+		symbols.Changes = "\uf044"     // nf-fa-pencil_square_o
+		symbols.Staged = "\uf067"      // nf-fa-plus
+		symbols.Modified = "\uf040"    // nf-fa-pencil
+		symbols.Deleted = "\uf068"     // nf-fa-minus
+		symbols.Renamed = "\uf074"     // nf-fa-random
+		symbols.Untracked = "\uf128"   // nf-fa-question
+		symbols.Conflicted = "\uf12a"  // nf-fa-exclamation
+		symbols.Error = "\uf071"       // nf-fa-warning
+		symbols.FetchError = "\uf0c2"  // nf-fa-cloud
+	case ThemeWords:
+		symbols.Upstream = "tracking:"
+		symbols.Ahead = "ahead:"
+		symbols.Behind = "behind:"
+		symbols.BaseAhead = "base-ahead:"
+		symbols.BaseBehind = "base-behind:"
+		symbols.Gone = "upstream-gone"
+		symbols.Diverged = "diverged"
+		symbols.FastForward = "fast-forward"
+		symbols.NoRemote = "no-remote"
+		symbols.Stashes = "stashed:"
+		symbols.Submodules = "submodules:"
+		symbols.Changes = "dirty"
+		symbols.Staged = "staged:"
+		symbols.Modified = "modified:"
+		symbols.Deleted = "deleted:"
+		symbols.Renamed = "renamed:"
+		symbols.Untracked = "untracked:"
+		symbols.Conflicted = "conflicted:"
+		symbols.Error = "error"
+		symbols.FetchError = "fetch-error"
+		symbols.Open = ""
+		symbols.Close = ""
+		symbols.Separator = ""
+		symbols.TreeBranch = "- "
+		symbols.TreeLast = "- "
+		symbols.TreeVertical = "  "
+		symbols.TreeSpace = "  "
+	default:
+		return Theme{}, false
+	}
+
+	return Theme{Name: name, Symbols: symbols, Palette: defaultPalette()}, true
+}
+
+// BuiltinThemeNames returns the names of the built-in themes in alphabetical order.
+func BuiltinThemeNames() []string {
+	names := []string{ThemeDefault, ThemeASCII, ThemeNerd, ThemeWords}
+	sort.Strings(names)
+
+	return names
+}
+
+// activeTheme holds the theme used by GitStatus.Format, Indicator.Colored and the tree formatters.
+//
+//nolint:gochecknoglobals // Process-wide display setting, like color.NoColor; set once at startup.
+var activeTheme = struct {
+	sync.RWMutex
+
+	theme  Theme
+	colors [4]func(a ...any) string
+}{}
+
+//nolint:gochecknoinits // Installs the default theme so the package works without configuration.
+func init() {
+	theme, _ := BuiltinTheme(ThemeDefault)
+	SetTheme(theme)
+}
+
+// SetTheme makes theme the active theme for all status and tree rendering.
+func SetTheme(theme Theme) {
+	activeTheme.Lock()
+	defer activeTheme.Unlock()
+
+	activeTheme.theme = theme
+	activeTheme.colors = [4]func(a ...any) string{
+		ColorGray:   colorFunc(theme.Palette.Gray),
+		ColorYellow: colorFunc(theme.Palette.Yellow),
+		ColorGreen:  colorFunc(theme.Palette.Green),
+		ColorRed:    colorFunc(theme.Palette.Red),
+	}
+}
+
+// colorFunc returns a function wrapping text in the given color attributes.
+// Without attributes the text is returned unchanged.
+func colorFunc(attrs []color.Attribute) func(a ...any) string {
+	if len(attrs) == 0 {
+		return fmt.Sprint
+	}
+
+	return color.New(attrs...).SprintFunc()
+}
+
+// CurrentTheme returns the active theme.
+func CurrentTheme() Theme {
+	activeTheme.RLock()
+	defer activeTheme.RUnlock()
+
+	return activeTheme.theme
+}
+
+// themeColor returns the active theme's color function for c.
+func themeColor(c IndicatorColor) func(a ...any) string {
+	activeTheme.RLock()
+	defer activeTheme.RUnlock()
+
+	if c < ColorGray || c > ColorRed {
+		c = ColorGray
+	}
+
+	return activeTheme.colors[c]
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTheme activates a built-in theme for the duration of a test.
+func useTheme(t *testing.T, name string) {
+	t.Helper()
+
+	previous := CurrentTheme()
+	theme, ok := BuiltinTheme(name)
+	require.True(t, ok, "built-in theme %q", name)
+	SetTheme(theme)
+	t.Cleanup(func() { SetTheme(previous) })
+}
+
+// Test GitStatus.Format renders the symbols of each built-in theme.
+func TestGitStatusFormat_Themes(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := &GitStatus{Branch: "main", HasRemote: true, Ahead: 2, Behind: 1, HasStashes: true, StashCount: 3, HasChanges: true}
+
+	tests := []struct {
+		theme string
+		want  string
+	}{
+		{ThemeDefault, "[[ main | ⇕ ↑2 ↓1 $3 * ]]"},
+		{ThemeASCII, "[[ main | <> >2 <1 $3 * ]]"},
+		{ThemeNerd, "[[ main | \uf126 \uf0622 \uf0631 \uf01c3 \uf044 ]]"},
+		{ThemeWords, "main diverged ahead:2 behind:1 stashed:3 dirty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.theme, func(t *testing.T) {
+			useTheme(t, tt.theme)
+			assert.Equal(t, tt.want, status.Format())
+		})
+	}
+}
+
+// Test each built-in theme marks a branch that can be fast-forwarded, so it differs from a plain
+// behind count without color.
+func TestGitStatusFormat_FastForwardThemes(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	status := &GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main", Behind: 2}
+
+	tests := []struct {
+		theme string
+		want  string
+	}{
+		{ThemeDefault, "[[ main | ff ↓2 ]]"},
+		{ThemeASCII, "[[ main | ff <2 ]]"},
+		{ThemeNerd, "[[ main | \uf050 \uf0632 ]]"},
+		{ThemeWords, "main fast-forward behind:2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.theme, func(t *testing.T) {
+			useTheme(t, tt.theme)
+			assert.Equal(t, tt.want, status.Format())
+		})
+	}
+}
+
+// Test the words theme omits brackets when only the branch is shown.
+func TestGitStatusFormat_WordsBranchOnly(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	useTheme(t, ThemeWords)
+
+	assert.Equal(t, "main", (&GitStatus{Branch: "main", HasRemote: true}).Format())
+	assert.Equal(t, "main no-remote", (&GitStatus{Branch: "main"}).Format())
+}
+
+// Test the words theme reports stashes as "stashed", with the count when it is known.
+func TestGitStatusFormat_WordsStashed(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	useTheme(t, ThemeWords)
+
+	assert.Equal(t, "main stashed:2", (&GitStatus{Branch: "main", HasRemote: true, HasStashes: true, StashCount: 2}).Format())
+	assert.Equal(t, "main stashed", (&GitStatus{Branch: "main", HasRemote: true, HasStashes: true}).Format())
+}
+
+// Test SetTheme applies the palette, and an empty palette entry disables color.
+func TestSetTheme_Palette(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = origNoColor }()
+
+	theme, _ := BuiltinTheme(ThemeDefault)
+	theme.Palette.Red = []color.Attribute{color.FgBlue}
+	theme.Palette.Gray = nil
+
+	previous := CurrentTheme()
+	SetTheme(theme)
+	defer SetTheme(previous)
+
+	assert.Equal(t, "\x1b[34mx\x1b[0m", ColorText(ColorRed, "x"))
+	assert.Equal(t, "x", ColorText(ColorGray, "x"))
+}
+
+// Test BuiltinTheme rejects unknown names.
+func TestBuiltinTheme_Unknown(t *testing.T) {
+	_, ok := BuiltinTheme("neon")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"ascii", "default", "nerd", "words"}, BuiltinThemeNames())
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+)
+
+// ErrInvalidTrunkPattern is returned when a trunk branch pattern is not a valid glob.
+var ErrInvalidTrunkPattern = errors.New("invalid trunk branch pattern")
+
+// DefaultTrunkBranches returns the trunk branch patterns used when none are configured.
+func DefaultTrunkBranches() []string {
+	return []string{"main", "master"}
+}
+
+// trunkBranches holds the patterns matched by GitStatus.IsTrunk.
+//
+//nolint:gochecknoglobals // Process-wide setting like the active theme; set once at startup.
+var trunkBranches = struct {
+	sync.RWMutex
+
+	patterns []string
+}{patterns: DefaultTrunkBranches()}
+
+// SetTrunkBranches sets the branch names treated as trunk in every repository. Patterns are
+// path.Match globs, so "release/*" matches "release/1.0" but not "release/1.0/hotfix".
+// An empty list restores the defaults.
+func SetTrunkBranches(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrInvalidTrunkPattern, pattern, err)
+		}
+	}
+	if len(patterns) == 0 {
+		patterns = DefaultTrunkBranches()
+	}
+
+	trunkBranches.Lock()
+	defer trunkBranches.Unlock()
+
+	trunkBranches.patterns = append([]string(nil), patterns...)
+
+	return nil
+}
+
+// TrunkBranches returns the configured trunk branch patterns.
+func TrunkBranches() []string {
+	trunkBranches.RLock()
+	defer trunkBranches.RUnlock()
+
+	return append([]string(nil), trunkBranches.patterns...)
+}
+
+// IsTrunk reports whether the checked-out branch is a trunk branch: the repository's default
+// branch, or a branch matching one of the configured trunk patterns. A detached HEAD or an
+// unknown branch is never trunk.
+func (g *GitStatus) IsTrunk() bool {
+	if g.IsDetached || g.Branch == "" || g.Branch == "N/A" {
+		return false
+	}
+	if g.DefaultBranch != "" && g.Branch == g.DefaultBranch {
+		return true
+	}
+
+	trunkBranches.RLock()
+	defer trunkBranches.RUnlock()
+
+	for _, pattern := range trunkBranches.patterns {
+		if matched, _ := path.Match(pattern, g.Branch); matched {
+			return true
+		}
+	}
+
+	return false
+}
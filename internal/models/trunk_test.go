@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test IsTrunk() matches configured names, globs and the repository's default branch.
+func TestGitStatusIsTrunk(t *testing.T) {
+	t.Cleanup(func() { _ = SetTrunkBranches(nil) })
+
+	tests := []struct {
+		name     string
+		patterns []string
+		status   GitStatus
+		expected bool
+	}{
+		{"default main", nil, GitStatus{Branch: "main"}, true},
+		{"default master", nil, GitStatus{Branch: "master"}, true},
+		{"default feature", nil, GitStatus{Branch: "develop"}, false},
+		{"default branch from origin/HEAD", nil, GitStatus{Branch: "develop", DefaultBranch: "develop"}, true},
+		{"configured name", []string{"develop"}, GitStatus{Branch: "develop"}, true},
+		{"configured names replace defaults", []string{"develop"}, GitStatus{Branch: "main"}, false},
+		{"glob", []string{"release/*"}, GitStatus{Branch: "release/1.0"}, true},
+		{"glob does not cross slashes", []string{"release/*"}, GitStatus{Branch: "release/1.0/hotfix"}, false},
+		{"detached never trunk", []string{"*"}, GitStatus{Branch: "DETACHED", IsDetached: true}, false},
+		{"unknown branch never trunk", []string{"*"}, GitStatus{Branch: "N/A"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetTrunkBranches(tt.patterns))
+			assert.Equal(t, tt.expected, tt.status.IsTrunk())
+		})
+	}
+}
+
+// Test SetTrunkBranches() rejects malformed globs and keeps the previous patterns.
+func TestSetTrunkBranches_InvalidPattern(t *testing.T) {
+	t.Cleanup(func() { _ = SetTrunkBranches(nil) })
+
+	require.NoError(t, SetTrunkBranches([]string{"develop"}))
+	err := SetTrunkBranches([]string{"release/["})
+	require.ErrorIs(t, err, ErrInvalidTrunkPattern)
+	assert.Equal(t, []string{"develop"}, TrunkBranches())
+
+	require.NoError(t, SetTrunkBranches(nil))
+	assert.Equal(t, DefaultTrunkBranches(), TrunkBranches())
+}
+
+// Test a clean repository on its default branch is standard and shown in gray.
+func TestIsStandardStatus_DefaultBranch(t *testing.T) {
+	status := GitStatus{Branch: "develop", DefaultBranch: "develop", HasRemote: true}
+	assert.True(t, status.IsStandardStatus())
+	assert.Equal(t, ColorGray, status.Indicators()[0].Color)
+
+	status.DefaultBranch = "main"
+	assert.False(t, status.IsStandardStatus())
+	assert.Equal(t, ColorYellow, status.Indicators()[0].Color)
+}
@@ -0,0 +1,132 @@
+//go:build !windows
+
+package reposcan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const gitFilePrefix = "gitdir:"
+
+var errInvalidGitFile = errors.New("invalid .git file")
+
+// GitDirInfo describes where the Git metadata of a working tree lives.
+type GitDirInfo struct {
+	GitDir    string // Git directory of the working tree (e.g. /src/app/.git or /src/app/.git/worktrees/feature)
+	CommonDir string // Git directory shared by all worktrees of the repository (e.g. /src/app/.git)
+}
+
+// IsLinkedWorktree reports whether the working tree was created by git worktree add.
+// Submodule checkouts and --separate-git-dir clones also use a .git file, but own their git directory.
+func (i GitDirInfo) IsLinkedWorktree() bool {
+	return i.GitDir != i.CommonDir
+}
+
+// MainPath returns the path of the main repository: the working tree owning CommonDir,
+// or CommonDir itself when it is not named .git (a bare repository or separate git directory).
+func (i GitDirInfo) MainPath() string {
+	if filepath.Base(i.CommonDir) == ".git" {
+		return filepath.Dir(i.CommonDir)
+	}
+
+	return i.CommonDir
+}
+
+// ResolveGitDir locates the git directory of the working tree at path. A .git directory is
+// used as is; a .git file ("gitdir: <path>") written by git worktree add, submodules or
+// git clone --separate-git-dir is followed to the directory it names. The common directory
+// is read from the commondir file of linked worktrees.
+func ResolveGitDir(path string) (GitDirInfo, error) {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return GitDirInfo{}, err
+	}
+
+	gitDir := dotGit
+	if !info.IsDir() {
+		gitDir, err = readGitFile(dotGit)
+		if err != nil {
+			return GitDirInfo{}, err
+		}
+	}
+
+	return GitDirInfo{GitDir: gitDir, CommonDir: readCommonDir(gitDir)}, nil
+}
+
+// readGitFile returns the git directory named by a .git file, resolved relative to the file.
+// The target must contain a HEAD file, so stale files of pruned worktrees are rejected.
+func readGitFile(dotGit string) (string, error) {
+	data, err := os.ReadFile(dotGit) //#nosec G304 -- path is a .git file found while scanning
+	if err != nil {
+		return "", err
+	}
+
+	target, found := strings.CutPrefix(strings.TrimSpace(string(data)), gitFilePrefix)
+	if !found {
+		return "", fmt.Errorf("%w: %s: missing %q prefix", errInvalidGitFile, dotGit, gitFilePrefix)
+	}
+
+	gitDir := strings.TrimSpace(target)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(dotGit), gitDir)
+	}
+	gitDir = filepath.Clean(gitDir)
+
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return "", fmt.Errorf("%w: %s: git directory %s is not accessible: %w", errInvalidGitFile, dotGit, gitDir, err)
+	}
+
+	return gitDir, nil
+}
+
+// readCommonDir returns the directory named by gitDir/commondir, or gitDir if there is none.
+func readCommonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir")) //#nosec G304 -- path is inside a git directory
+	if err != nil {
+		return gitDir
+	}
+
+	commonDir := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+
+	return filepath.Clean(commonDir)
+}
+
+// ListWorktrees returns the paths of the linked worktrees registered in a repository's
+// common git directory, sorted. Worktrees whose directory no longer exists are omitted.
+func ListWorktrees(commonDir string) []string {
+	entries, err := os.ReadDir(filepath.Join(commonDir, "worktrees"))
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		// The gitdir file holds the path of the worktree's .git file
+		data, err := os.ReadFile(filepath.Join(commonDir, "worktrees", entry.Name(), "gitdir")) //#nosec G304 -- inside a git directory
+		if err != nil {
+			continue
+		}
+
+		worktreePath := filepath.Dir(filepath.Clean(strings.TrimSpace(string(data))))
+		if info, err := os.Stat(worktreePath); err == nil && info.IsDir() {
+			paths = append(paths, worktreePath)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
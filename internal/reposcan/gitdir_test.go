@@ -0,0 +1,132 @@
+//go:build !windows
+
+package reposcan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestWorktree registers a linked worktree of mainPath at worktreePath, the way
+// git worktree add lays it out: a .git file in the worktree and a per-worktree git
+// directory below <main>/.git/worktrees with commondir and gitdir files.
+func createTestWorktree(t *testing.T, mainPath, worktreePath, name string) {
+	t.Helper()
+
+	adminDir := filepath.Join(mainPath, ".git", "worktrees", name)
+	require.NoError(t, os.MkdirAll(adminDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(adminDir, "HEAD"), []byte("ref: refs/heads/"+name+"\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(adminDir, "commondir"), []byte("../..\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(adminDir, "gitdir"), []byte(filepath.Join(worktreePath, ".git")+"\n"), 0o600))
+
+	require.NoError(t, os.MkdirAll(worktreePath, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, ".git"), []byte("gitdir: "+adminDir+"\n"), 0o600))
+}
+
+// Test ResolveGitDir for a .git directory, a linked worktree and a relative .git file.
+func TestResolveGitDir(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "app")
+	createTestRepo(t, mainPath, false)
+	createTestWorktree(t, mainPath, filepath.Join(tempDir, "app-feature"), "feature")
+
+	info, err := ResolveGitDir(mainPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(mainPath, ".git"), info.GitDir)
+	assert.Equal(t, info.GitDir, info.CommonDir)
+	assert.False(t, info.IsLinkedWorktree())
+
+	info, err = ResolveGitDir(filepath.Join(tempDir, "app-feature"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(mainPath, ".git", "worktrees", "feature"), info.GitDir)
+	assert.Equal(t, filepath.Join(mainPath, ".git"), info.CommonDir)
+	assert.True(t, info.IsLinkedWorktree())
+	assert.Equal(t, mainPath, info.MainPath())
+
+	// A --separate-git-dir clone uses a relative .git file and owns its git directory
+	separate := filepath.Join(tempDir, "separate")
+	createTestRepo(t, filepath.Join(tempDir, "store"), true)
+	require.NoError(t, os.MkdirAll(separate, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(separate, ".git"), []byte("gitdir: ../store\n"), 0o600))
+
+	info, err = ResolveGitDir(separate)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tempDir, "store"), info.GitDir)
+	assert.False(t, info.IsLinkedWorktree())
+}
+
+// Test ResolveGitDir rejects malformed .git files and pruned worktrees.
+func TestResolveGitDir_Invalid(t *testing.T) {
+	tempDir := t.TempDir()
+
+	malformed := filepath.Join(tempDir, "malformed")
+	require.NoError(t, os.MkdirAll(malformed, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(malformed, ".git"), []byte("not a gitdir line\n"), 0o600))
+
+	_, err := ResolveGitDir(malformed)
+	require.ErrorIs(t, err, errInvalidGitFile)
+
+	stale := filepath.Join(tempDir, "stale")
+	require.NoError(t, os.MkdirAll(stale, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(stale, ".git"), []byte("gitdir: /nonexistent/.git/worktrees/x\n"), 0o600))
+
+	_, err = ResolveGitDir(stale)
+	require.ErrorIs(t, err, errInvalidGitFile)
+
+	isRepo, _ := IsGitRepository(stale)
+	assert.False(t, isRepo, "a .git file pointing nowhere is not a repository")
+}
+
+// Test ListWorktrees returns registered worktrees that still exist.
+func TestListWorktrees(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "app")
+	createTestRepo(t, mainPath, false)
+	createTestWorktree(t, mainPath, filepath.Join(tempDir, "wt-b"), "b")
+	createTestWorktree(t, mainPath, filepath.Join(tempDir, "wt-a"), "a")
+	createTestWorktree(t, mainPath, filepath.Join(tempDir, "gone"), "gone")
+	require.NoError(t, os.RemoveAll(filepath.Join(tempDir, "gone")))
+
+	assert.Equal(t, []string{filepath.Join(tempDir, "wt-a"), filepath.Join(tempDir, "wt-b")},
+		ListWorktrees(filepath.Join(mainPath, ".git")))
+	assert.Empty(t, ListWorktrees(filepath.Join(tempDir, "missing")))
+}
+
+// Test Scan finds linked worktrees, including those inside their main repository.
+func TestScan_FindsLinkedWorktrees(t *testing.T) {
+	tempDir := t.TempDir()
+	mainPath := filepath.Join(tempDir, "app")
+	createTestRepo(t, mainPath, false)
+	createTestWorktree(t, mainPath, filepath.Join(tempDir, "app-feature"), "feature")
+	createTestWorktree(t, mainPath, filepath.Join(mainPath, ".worktrees", "fix"), "fix")
+	createTestWorktree(t, mainPath, filepath.Join(t.TempDir(), "elsewhere"), "elsewhere")
+
+	result, err := Scan(context.Background(), ScanOptions{RootPath: tempDir})
+	require.NoError(t, err)
+
+	byPath := make(map[string]bool)
+	for _, repo := range result.Repositories {
+		byPath[repo.Path] = true
+		switch repo.Path {
+		case mainPath:
+			assert.False(t, repo.IsWorktree)
+			assert.Equal(t, filepath.Join(mainPath, ".git"), repo.CommonDir)
+			assert.Empty(t, repo.GitDir)
+		default:
+			assert.True(t, repo.IsWorktree, repo.Path)
+			assert.Equal(t, mainPath, repo.WorktreeOf)
+			assert.Equal(t, filepath.Join(mainPath, ".git"), repo.CommonDir)
+			assert.NotEmpty(t, repo.GitDir)
+		}
+	}
+
+	assert.Len(t, result.Repositories, 3, "worktrees outside the scan root are not added")
+	assert.True(t, byPath[filepath.Join(tempDir, "app-feature")])
+	assert.True(t, byPath[filepath.Join(mainPath, ".worktrees", "fix")])
+	assert.Equal(t, 3, result.TotalRepos)
+}
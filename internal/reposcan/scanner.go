@@ -9,6 +9,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -25,11 +27,19 @@ type ScanOptions struct {
 // Returns (isRepo, isBare) where:
 // - isRepo: true if directory contains a Git repository
 // - isBare: true if it's a bare repository.
+//
+// A .git file pointing to a valid git directory (linked worktree, submodule checkout or
+// --separate-git-dir clone) counts as a regular repository.
 func IsGitRepository(path string) (isRepo, isBare bool) {
 	// Check for regular repository (.git directory)
 	gitDir := filepath.Join(path, ".git")
-	if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
-		return true, false // regular repo
+	if info, err := os.Stat(gitDir); err == nil {
+		if info.IsDir() {
+			return true, false // regular repo
+		}
+		if _, err := ResolveGitDir(path); err == nil {
+			return true, false // .git file
+		}
 	}
 
 	// Check for bare repository (HEAD, refs/, objects/ in root)
@@ -119,6 +129,9 @@ func Scan(ctx context.Context, opts ScanOptions) (*models.ScanResult, error) {
 		return nil, fmt.Errorf("error walking directory tree: %w", err)
 	}
 
+	// Worktrees placed inside their repository are never walked into, so add them from the registry
+	s.addRegisteredWorktrees()
+
 	result := &models.ScanResult{
 		RootPath:     absPath,
 		Repositories: s.repositories,
@@ -192,6 +205,7 @@ func (s *scanner) walkFunc(ctx context.Context, path string, d fs.DirEntry, err
 			IsBare:    isBare,
 			IsSymlink: isSymlink,
 		}
+		s.setGitDir(repo)
 
 		s.repositories = append(s.repositories, repo)
 
@@ -257,3 +271,65 @@ func (s *scanner) shouldVisit(path string) (shouldVisit, isSymlink bool, err err
 
 	return true, isSymlink, nil
 }
+
+// setGitDir records where the repository's git directory lives and whether it is a linked worktree.
+func (s *scanner) setGitDir(repo *models.Repository) {
+	if repo.IsBare {
+		repo.CommonDir = repo.Path
+
+		return
+	}
+
+	info, err := ResolveGitDir(repo.Path)
+	if err != nil {
+		debugPrintf(s.opts.Debug, "Cannot resolve git directory of %s: %v", repo.Path, err)
+
+		return
+	}
+
+	repo.CommonDir = info.CommonDir
+	if info.GitDir != filepath.Join(repo.Path, ".git") {
+		repo.GitDir = info.GitDir
+	}
+	if info.IsLinkedWorktree() {
+		repo.IsWorktree = true
+		repo.WorktreeOf = info.MainPath()
+		debugPrintf(s.opts.Debug, "Repository %s is a linked worktree of %s", repo.Path, repo.WorktreeOf)
+	}
+}
+
+// addRegisteredWorktrees adds the linked worktrees registered in the found repositories
+// that lie inside the scan root but were not reached by the directory walk.
+func (s *scanner) addRegisteredWorktrees() {
+	known := make(map[string]bool, len(s.repositories))
+	for _, repo := range s.repositories {
+		known[repo.Path] = true
+	}
+
+	mains := slices.Clone(s.repositories)
+	for _, owner := range mains {
+		if owner.IsWorktree || owner.CommonDir == "" {
+			continue
+		}
+
+		for _, path := range ListWorktrees(owner.CommonDir) {
+			relPath, err := filepath.Rel(s.rootPath, path)
+			if known[path] || err != nil || relPath == ".." || strings.HasPrefix(relPath, "../") {
+				continue
+			}
+
+			debugPrintf(s.opts.Debug, "Found registered worktree: %s", path)
+			repo := &models.Repository{
+				Path: path,
+				Name: filepath.Base(path),
+			}
+			s.setGitDir(repo)
+			if !repo.IsWorktree {
+				continue
+			}
+
+			known[path] = true
+			s.repositories = append(s.repositories, repo)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/andreygrechin/gitree/internal/models"
 )
@@ -16,6 +18,39 @@ type FormatOptions struct {
 
 	// RootLabel is the label to use for the root (e.g., ".")
 	RootLabel string
+
+	// Compact merges chains of intermediate directories that have exactly one child
+	// into a single node labeled with the joined path (e.g. "src/github.com/acme/api")
+	Compact bool
+
+	// SortBy selects the order of sibling nodes; empty means alphabetical by name
+	SortBy models.SortKey
+
+	// GroupBy, if set, groups repositories by branch, remote host or status category
+	// instead of following the directory hierarchy
+	GroupBy GroupKey
+
+	// LineTemplate, if set, renders the text of each node line after the tree connector
+	// instead of the built-in name and status layout. See LineData for the available fields.
+	LineTemplate *template.Template
+
+	// Width is the maximum line width of the table layout; zero or negative means unlimited
+	Width int
+
+	// ShowAge appends the age of each repository's last commit (e.g. "3d ago") to its tree line
+	ShowAge bool
+
+	// Now is the reference time for commit ages; zero means time.Now()
+	Now time.Time
+}
+
+// now returns the reference time for commit ages.
+func (o *FormatOptions) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+
+	return o.Now
 }
 
 // DefaultFormatOptions returns sensible defaults.
@@ -42,14 +77,33 @@ func Build(rootPath string, repos []*models.Repository, opts *FormatOptions) *mo
 		IsLast:       false,
 		Children:     make([]*models.TreeNode, 0),
 		RelativePath: opts.RootLabel,
+		IsDirectory:  true,
 	}
 
 	if repos == nil {
 		return root
 	}
 
+	if opts.GroupBy != GroupByNone {
+		return buildGrouped(root, rootPath, repos, opts)
+	}
+
+	// Linked worktrees are shown below their main repository when it is part of the tree
+	listed := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		listed[repo.Path] = true
+	}
+	owners := make(map[string]*models.TreeNode)
+	var worktrees []*models.Repository
+
 	// Build tree by organizing repos into hierarchy
 	for _, repo := range repos {
+		if repo.IsWorktree && listed[repo.WorktreeOf] {
+			worktrees = append(worktrees, repo)
+
+			continue
+		}
+
 		relPath, err := filepath.Rel(rootPath, repo.Path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "WARN: %s\n", fmt.Sprintf("failed to get relative path: %v", err))
@@ -58,17 +112,48 @@ func Build(rootPath string, repos []*models.Repository, opts *FormatOptions) *mo
 		}
 
 		// Create or find nodes for this repository
-		insertIntoTree(root, repo, relPath, rootPath)
+		owners[repo.Path] = insertIntoTree(root, repo, relPath, rootPath)
+		addSubmoduleNodes(owners[repo.Path], rootPath)
+	}
+
+	for _, repo := range worktrees {
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: %s\n", fmt.Sprintf("failed to get relative path: %v", err))
+
+			continue
+		}
+
+		owner, exists := owners[repo.WorktreeOf]
+		if !exists {
+			addSubmoduleNodes(insertIntoTree(root, repo, relPath, rootPath), rootPath)
+
+			continue
+		}
+
+		// Label with the path, since the worktree may live anywhere below the scan root
+		worktreeNode := &models.TreeNode{
+			Repository:   repo,
+			Children:     make([]*models.TreeNode, 0),
+			RelativePath: relPath,
+			Label:        filepath.ToSlash(relPath),
+		}
+		owner.Children = append(owner.Children, worktreeNode)
+		addSubmoduleNodes(worktreeNode, rootPath)
 	}
 
-	// Sort all children alphabetically and mark IsLast flags
-	sortTree(root)
+	if opts.Compact {
+		compactTree(root)
+	}
+
+	// Sort all children and mark IsLast flags
+	sortTree(root, opts.SortBy)
 
 	return root
 }
 
-// insertIntoTree inserts a repository into the tree at the correct location.
-func insertIntoTree(root *models.TreeNode, repo *models.Repository, relPath, rootPath string) {
+// insertIntoTree inserts a repository into the tree at the correct location and returns its node.
+func insertIntoTree(root *models.TreeNode, repo *models.Repository, relPath, rootPath string) *models.TreeNode {
 	parts := strings.Split(filepath.ToSlash(relPath), "/")
 
 	current := root
@@ -99,6 +184,7 @@ func insertIntoTree(root *models.TreeNode, repo *models.Repository, relPath, roo
 				},
 				Children:     make([]*models.TreeNode, 0),
 				RelativePath: strings.Join(parts[:i+1], "/"),
+				IsDirectory:  true,
 			}
 			current.Children = append(current.Children, newNode)
 			current = newNode
@@ -112,21 +198,71 @@ func insertIntoTree(root *models.TreeNode, repo *models.Repository, relPath, roo
 		RelativePath: relPath,
 	}
 	current.Children = append(current.Children, repoNode)
+
+	return repoNode
+}
+
+// addSubmoduleNodes adds a child node for each submodule of the repository at node, labeled with
+// the submodule path, and recurses into nested submodules. The scanner stops at repository roots,
+// so submodule checkouts are never also part of the scanned list.
+func addSubmoduleNodes(node *models.TreeNode, rootPath string) {
+	status := node.Repository.GitStatus
+	if status == nil {
+		return
+	}
+
+	for _, sub := range status.Submodules {
+		subPath := filepath.Join(node.Repository.Path, filepath.FromSlash(sub.Path))
+		relPath, err := filepath.Rel(rootPath, subPath)
+		if err != nil {
+			relPath = subPath
+		}
+
+		child := &models.TreeNode{
+			Repository: &models.Repository{
+				Path:           subPath,
+				Name:           filepath.Base(subPath),
+				GitStatus:      sub.Status,
+				SubmoduleOf:    node.Repository.Path,
+				SubmoduleState: sub.State,
+			},
+			Children:     make([]*models.TreeNode, 0),
+			RelativePath: filepath.ToSlash(relPath),
+			Label:        sub.Path,
+		}
+		node.Children = append(node.Children, child)
+		addSubmoduleNodes(child, rootPath)
+	}
+}
+
+// compactTree recursively collapses single-child directory chains below node.
+// The node itself is never collapsed, so the scan root keeps its label.
+func compactTree(node *models.TreeNode) {
+	for i, child := range node.Children {
+		// Fold each intermediate directory with a single child into that child
+		for child.IsDirectory && len(child.Children) == 1 {
+			next := child.Children[0]
+			next.Label = child.Name() + "/" + next.Name()
+			child = next
+		}
+		node.Children[i] = child
+		compactTree(child)
+	}
 }
 
-// sortTree recursively sorts all children alphabetically and sets depth/IsLast flags.
-func sortTree(node *models.TreeNode) {
+// sortTree recursively sorts all children by key and sets depth/IsLast flags.
+func sortTree(node *models.TreeNode, key models.SortKey) {
 	if node == nil {
 		return
 	}
 
-	// Sort children using the TreeNode.SortChildren method
-	node.SortChildren()
+	// Sort children using the TreeNode.SortChildrenBy method
+	node.SortChildrenBy(key)
 
 	// Recursively sort all children and set their depth
 	for _, child := range node.Children {
 		child.Depth = node.Depth + 1
-		sortTree(child)
+		sortTree(child, key)
 	}
 }
 
@@ -159,28 +295,86 @@ func Format(root *models.TreeNode, opts *FormatOptions) string {
 	// Format children
 	for i, child := range root.Children {
 		isLast := (i == len(root.Children)-1)
-		formatNode(&builder, child, "", isLast)
+		formatNode(&builder, child, "", isLast, opts)
 	}
 
 	return builder.String()
 }
 
 // formatNode recursively formats a tree node with appropriate connectors.
-func formatNode(builder *strings.Builder, node *models.TreeNode, prefix string, isLast bool) {
+func formatNode(builder *strings.Builder, node *models.TreeNode, prefix string, isLast bool, opts *FormatOptions) {
 	if node == nil || node.Repository == nil {
 		return
 	}
 
 	// Choose connector based on whether this is the last child
-	connector := "├── "
+	symbols := models.CurrentTheme().Symbols
+	connector := symbols.TreeBranch
 	if isLast {
-		connector = "└── "
+		connector = symbols.TreeLast
 	}
 
 	// Write the node line
 	builder.WriteString(prefix)
 	builder.WriteString(connector)
-	builder.WriteString(node.Repository.Name)
+	if opts.LineTemplate != nil {
+		builder.WriteString(renderLineTemplate(opts.LineTemplate, node))
+	} else {
+		writeNodeText(builder, node, opts)
+	}
+	builder.WriteString("\n")
+
+	// Format children with updated prefix
+	childPrefix := prefix
+	if isLast {
+		childPrefix += symbols.TreeSpace // Blank continuation below the last child
+	} else {
+		childPrefix += symbols.TreeVertical // Vertical bar continuation for non-last
+	}
+
+	// Local branches come first, as leaves above the nested repositories
+	branches := nodeBranches(node)
+	total := len(branches) + len(node.Children)
+	for i, branch := range branches {
+		connector := symbols.TreeBranch
+		if i == total-1 {
+			connector = symbols.TreeLast
+		}
+		builder.WriteString(childPrefix + connector + formatBranchLine(branch, opts.now()) + "\n")
+	}
+
+	for i, child := range node.Children {
+		childIsLast := (len(branches)+i == total-1)
+		formatNode(builder, child, childPrefix, childIsLast, opts)
+	}
+}
+
+// nodeBranches returns the local branches reported for a repository node, if any.
+func nodeBranches(node *models.TreeNode) []models.BranchStatus {
+	if node.IsDirectory || node.Repository.GitStatus == nil {
+		return nil
+	}
+
+	return node.Repository.GitStatus.Branches
+}
+
+// formatBranchLine renders a local branch as its name, upstream or no-remote marker, unpushed
+// commit count and the age of its last commit, e.g. "feature →origin/feature ↑2 3d".
+func formatBranchLine(branch models.BranchStatus, now time.Time) string {
+	pieces := []string{branch.Name}
+	for _, ind := range branch.Indicators() {
+		pieces = append(pieces, ind.Colored())
+	}
+	if !branch.LastCommitDate.IsZero() {
+		pieces = append(pieces, models.ColorText(models.ColorGray, formatAge(now.Sub(branch.LastCommitDate))))
+	}
+
+	return strings.Join(pieces, " ")
+}
+
+// writeNodeText writes the built-in node text: the name followed by status and indicators.
+func writeNodeText(builder *strings.Builder, node *models.TreeNode, opts *FormatOptions) {
+	builder.WriteString(node.Name())
 
 	// Add Git status if available
 	if node.Repository.GitStatus != nil {
@@ -198,6 +392,19 @@ func formatNode(builder *strings.Builder, node *models.TreeNode, prefix string,
 		builder.WriteString(" timeout")
 	}
 
+	// Add worktree indicator if this is a linked worktree
+	if node.Repository.IsWorktree {
+		builder.WriteString(" worktree")
+	}
+
+	// Add submodule indicator, with the state unless the checkout matches the recorded commit
+	if node.Repository.SubmoduleOf != "" {
+		builder.WriteString(" submodule")
+		if node.Repository.SubmoduleState != models.SubmoduleInSync {
+			builder.WriteString(" " + string(node.Repository.SubmoduleState))
+		}
+	}
+
 	// Add bare indicator if this is a bare repository
 	if node.Repository.IsBare && node.Repository.GitStatus != nil {
 		// Check if "bare" is already in the status format
@@ -207,18 +414,9 @@ func formatNode(builder *strings.Builder, node *models.TreeNode, prefix string,
 		}
 	}
 
-	builder.WriteString("\n")
-
-	// Format children with updated prefix
-	childPrefix := prefix
-	if isLast {
-		childPrefix += "    " // Four spaces for last child
-	} else {
-		childPrefix += "│   " // Vertical bar and three spaces for non-last
-	}
-
-	for i, child := range node.Children {
-		childIsLast := (i == len(node.Children)-1)
-		formatNode(builder, child, childPrefix, childIsLast)
+	// Add the age of the last commit if requested
+	if opts.ShowAge && node.Repository.GitStatus != nil && !node.Repository.GitStatus.LastCommitDate.IsZero() {
+		age := formatRelativeAge(opts.now().Sub(node.Repository.GitStatus.LastCommitDate))
+		builder.WriteString(" " + models.ColorText(models.ColorGray, age))
 	}
 }
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -391,3 +393,210 @@ func TestFormat_VerticalPipeForNonLastChildren(t *testing.T) {
 	// When there's dir as non-last child with nested projects
 	assert.Contains(t, output, "│")
 }
+
+// Test Build with Compact merges single-child directory chains into one node.
+func TestBuild_CompactCollapsesDirectoryChains(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{
+			Path:      "/root/src/github.com/acme/api",
+			Name:      "api",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true},
+		},
+		{
+			Path:      "/root/src/gitlab.com/team/web",
+			Name:      "web",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true},
+		},
+		{
+			Path:      "/root/src/gitlab.com/team/cli",
+			Name:      "cli",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true},
+		},
+		{
+			Path:      "/root/tools",
+			Name:      "tools",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true},
+		},
+	}
+
+	opts := DefaultFormatOptions()
+	opts.Compact = true
+	output := Format(Build("/root", repos, opts), opts)
+
+	expected := `.
+├── src
+│   ├── github.com/acme/api [[ main ]]
+│   └── gitlab.com/team
+│       ├── cli [[ main ]]
+│       └── web [[ main ]]
+└── tools [[ main ]]
+`
+	assert.Equal(t, expected, output)
+
+	// The repository keeps its own name; only the node label changes
+	assert.Equal(t, "api", repos[0].Name)
+}
+
+// Test Build with Compact keeps nodes whose only child is nested inside a repository.
+func TestBuild_CompactKeepsRepositoryNodes(t *testing.T) {
+	repos := []*models.Repository{
+		{Path: "/root/outer", Name: "outer"},
+		{Path: "/root/outer/vendor/inner", Name: "inner"},
+	}
+
+	opts := DefaultFormatOptions()
+	opts.Compact = true
+	root := Build("/root", repos, opts)
+
+	require.Len(t, root.Children, 1)
+	outer := root.Children[0]
+	assert.Equal(t, "outer", outer.Name())
+	require.Len(t, outer.Children, 1)
+	assert.Equal(t, "vendor/inner", outer.Children[0].Name())
+	assert.Equal(t, 2, outer.Children[0].Depth)
+}
+
+// Test Build nests linked worktrees below their main repository, labeled with their paths.
+func TestBuild_NestsWorktreesUnderMainRepository(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{
+			Path:      "/root/work/app",
+			Name:      "app",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true},
+		},
+		{
+			Path:       "/root/app-feature",
+			Name:       "app-feature",
+			IsWorktree: true,
+			WorktreeOf: "/root/work/app",
+			GitStatus:  &models.GitStatus{Branch: "feature", HasRemote: true, Ahead: 1},
+		},
+		{
+			Path:       "/root/lib-fix",
+			Name:       "lib-fix",
+			IsWorktree: true,
+			WorktreeOf: "/root/lib",
+			GitStatus:  &models.GitStatus{Branch: "fix", HasRemote: true},
+		},
+	}
+
+	opts := DefaultFormatOptions()
+	output := Format(Build("/root", repos, opts), opts)
+
+	// lib is not in the list (e.g. filtered out as clean), so its worktree stays in place
+	expected := `.
+├── lib-fix [[ fix ]] worktree
+└── work
+    └── app [[ main ]]
+        └── app-feature [[ feature | ↑1 ]] worktree
+`
+	assert.Equal(t, expected, output)
+}
+
+// Test Build() adds submodules as child nodes of their parent repository.
+func TestBuild_AddsSubmoduleNodes(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{
+			Path: "/root/app",
+			Name: "app",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				Submodules: []models.SubmoduleStatus{
+					{Name: "core", Path: "libs/core", State: models.SubmoduleInSync, Status: &models.GitStatus{Branch: "DETACHED", IsDetached: true}},
+					{Name: "tool", Path: "vendor/tool", State: models.SubmoduleUninitialized},
+				},
+			},
+		},
+	}
+
+	opts := DefaultFormatOptions()
+	root := Build("/root", repos, opts)
+	output := Format(root, opts)
+
+	expected := `.
+└── app [[ main | §1 ]]
+    ├── libs/core [[ DETACHED | ○ ]] submodule
+    └── vendor/tool submodule uninitialized
+`
+	assert.Equal(t, expected, output)
+
+	core := root.Children[0].Children[0]
+	assert.Equal(t, "app/libs/core", core.RelativePath)
+	assert.Equal(t, "/root/app", core.Repository.SubmoduleOf)
+}
+
+// Test Format lists local branches as leaves above the repository's child nodes.
+func TestFormat_ListsLocalBranches(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	repos := []*models.Repository{
+		{
+			Path: "/root/app",
+			Name: "app",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				Branches: []models.BranchStatus{
+					{Name: "main", Upstream: "origin/main", LastCommitDate: now.Add(-2 * time.Hour), IsHead: true},
+					{Name: "spike", Unpushed: 3, LastCommitDate: now.Add(-40 * 24 * time.Hour)},
+				},
+				Submodules: []models.SubmoduleStatus{{Name: "tool", Path: "vendor/tool", State: models.SubmoduleInSync}},
+			},
+		},
+	}
+
+	opts := DefaultFormatOptions()
+	opts.Now = now
+	output := Format(Build("/root", repos, opts), opts)
+
+	expected := `.
+└── app [[ main ]]
+    ├── main →origin/main 2h
+    ├── spike ○ ↑3 40d
+    └── vendor/tool submodule
+`
+	assert.Equal(t, expected, output)
+}
+
+// Test Format appends the age of the last commit with ShowAge.
+func TestFormat_ShowAge(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	repos := []*models.Repository{
+		{Path: "/root/api", Name: "api", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, LastCommitDate: now.Add(-72 * time.Hour)}},
+		{Path: "/root/new", Name: "new", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, LastCommitDate: now}},
+		{Path: "/root/empty", Name: "empty", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true}},
+	}
+
+	opts := DefaultFormatOptions()
+	opts.Now = now
+	opts.ShowAge = true
+	output := Format(Build("/root", repos, opts), opts)
+
+	expected := `.
+├── api [[ main ]] 3d ago
+├── empty [[ main ]]
+└── new [[ main ]] just now
+`
+	assert.Equal(t, expected, output)
+
+	opts.ShowAge = false
+	assert.NotContains(t, Format(Build("/root", repos, opts), opts), "ago")
+}
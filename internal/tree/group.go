@@ -0,0 +1,191 @@
+package tree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// GroupKey selects how Build groups repositories instead of following the directory hierarchy.
+type GroupKey string
+
+// Group keys accepted in FormatOptions.GroupBy.
+const (
+	GroupByNone   GroupKey = ""       // Directory hierarchy (default)
+	GroupByBranch GroupKey = "branch" // Current branch name
+	GroupByRemote GroupKey = "remote" // Host of the primary remote
+	GroupByStatus GroupKey = "status" // Status category, most urgent first
+)
+
+// Placeholder group labels for repositories without a value for the group key.
+const (
+	groupUnknown  = "(unknown)"
+	groupNoRemote = "(no remote)"
+	groupLocal    = "(local)"
+)
+
+// Status categories used by GroupByStatus, from most to least urgent.
+const (
+	categoryError       = "error"
+	categoryInProgress  = "in-progress"
+	categoryDirty       = "dirty"
+	categoryDiverged    = "diverged"
+	categoryUnpushed    = "unpushed"
+	categoryBehind      = "behind"
+	categoryGone        = "gone"
+	categoryStashed     = "stashed"
+	categoryNoRemote    = "no-remote"
+	categoryDetached    = "detached"
+	categoryOtherBranch = "other-branch"
+	categoryClean       = "clean"
+)
+
+// statusCategoryOrder lists the status categories in display order.
+//
+//nolint:gochecknoglobals // Read-only category definition.
+var statusCategoryOrder = []string{
+	categoryError, categoryInProgress, categoryDirty, categoryDiverged, categoryUnpushed, categoryBehind, categoryGone,
+	categoryStashed, categoryNoRemote, categoryDetached, categoryOtherBranch, categoryClean,
+}
+
+// GroupKeys returns all supported group keys except GroupByNone.
+func GroupKeys() []GroupKey {
+	return []GroupKey{GroupByBranch, GroupByRemote, GroupByStatus}
+}
+
+// buildGrouped constructs a two-level tree: one directory-like node per group, each holding
+// the repositories of that group labeled with their relative paths.
+func buildGrouped(root *models.TreeNode, rootPath string, repos []*models.Repository, opts *FormatOptions) *models.TreeNode {
+	groups := make(map[string]*models.TreeNode)
+
+	for _, repo := range repos {
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: %s\n", fmt.Sprintf("failed to get relative path: %v", err))
+
+			continue
+		}
+
+		label := groupLabel(repo, opts.GroupBy)
+		group, exists := groups[label]
+		if !exists {
+			group = &models.TreeNode{
+				Repository: &models.Repository{
+					Path: rootPath,
+					Name: label,
+				},
+				Children:     make([]*models.TreeNode, 0),
+				RelativePath: label,
+				IsDirectory:  true,
+			}
+			groups[label] = group
+			root.Children = append(root.Children, group)
+		}
+
+		group.Children = append(group.Children, &models.TreeNode{
+			Repository:   repo,
+			Children:     make([]*models.TreeNode, 0),
+			RelativePath: relPath,
+			Label:        filepath.ToSlash(relPath),
+		})
+	}
+
+	sort.SliceStable(root.Children, func(i, j int) bool {
+		a, b := root.Children[i].Name(), root.Children[j].Name()
+		if rankA, rankB := groupRank(a, opts.GroupBy), groupRank(b, opts.GroupBy); rankA != rankB {
+			return rankA < rankB
+		}
+
+		return a < b
+	})
+
+	for i, group := range root.Children {
+		group.Depth = 1
+		group.IsLast = (i == len(root.Children)-1)
+		group.SortChildrenBy(opts.SortBy)
+		for _, child := range group.Children {
+			child.Depth = 2
+		}
+	}
+
+	return root
+}
+
+// groupLabel returns the name of the group a repository belongs to.
+func groupLabel(repo *models.Repository, key GroupKey) string {
+	status := repo.GitStatus
+
+	switch key {
+	case GroupByBranch:
+		if status == nil || status.Branch == "" || status.Branch == "N/A" {
+			return groupUnknown
+		}
+
+		return status.Branch
+	case GroupByRemote:
+		switch {
+		case status == nil:
+			return groupUnknown
+		case !status.HasRemote:
+			return groupNoRemote
+		case status.RemoteHost == "":
+			return groupLocal
+		default:
+			return status.RemoteHost
+		}
+	case GroupByStatus:
+		return statusCategory(repo)
+	default:
+		return groupUnknown
+	}
+}
+
+// groupRank orders groups: status categories by urgency, placeholder labels last.
+func groupRank(label string, key GroupKey) int {
+	if key == GroupByStatus {
+		return slices.Index(statusCategoryOrder, label)
+	}
+
+	if strings.HasPrefix(label, "(") {
+		return 1
+	}
+
+	return 0
+}
+
+// statusCategory returns the most urgent status category that applies to a repository.
+func statusCategory(repo *models.Repository) string {
+	status := repo.GitStatus
+
+	switch {
+	case status == nil || repo.Error != nil || status.Error != "" || status.FetchError != "":
+		return categoryError
+	case status.Operation != "":
+		return categoryInProgress
+	case status.HasChanges || status.SubmodulesNeedingAttention() > 0:
+		return categoryDirty
+	case status.Ahead > 0 && status.Behind > 0:
+		return categoryDiverged
+	case status.Ahead > 0:
+		return categoryUnpushed
+	case status.Behind > 0:
+		return categoryBehind
+	case status.UpstreamGone:
+		return categoryGone
+	case status.HasStashes:
+		return categoryStashed
+	case !status.HasRemote:
+		return categoryNoRemote
+	case status.IsDetached:
+		return categoryDetached
+	case !status.IsTrunk():
+		return categoryOtherBranch
+	default:
+		return categoryClean
+	}
+}
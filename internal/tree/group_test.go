@@ -0,0 +1,149 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGroupTestRepos returns repositories covering several branches, hosts and categories.
+func newGroupTestRepos() []*models.Repository {
+	return []*models.Repository{
+		{
+			Path:      "/root/svc/api",
+			Name:      "api",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, RemoteHost: "github.com", Ahead: 1},
+		},
+		{
+			Path:      "/root/svc/web",
+			Name:      "web",
+			GitStatus: &models.GitStatus{Branch: "feature", HasRemote: true, RemoteHost: "github.com", Ahead: 4},
+		},
+		{
+			Path:      "/root/notes",
+			Name:      "notes",
+			GitStatus: &models.GitStatus{Branch: "main", HasChanges: true},
+		},
+		{
+			Path:  "/root/broken",
+			Name:  "broken",
+			Error: ErrCorruptedRepository,
+		},
+	}
+}
+
+// Test Build with GroupBy renders groups of repositories labeled with their paths.
+func TestBuild_GroupByBranch(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	opts := DefaultFormatOptions()
+	opts.GroupBy = GroupByBranch
+	root := Build("/root", newGroupTestRepos(), opts)
+
+	expected := `.
+├── feature
+│   └── svc/web [[ feature | ↑4 ]]
+├── main
+│   ├── notes [[ main | ○ * ]]
+│   └── svc/api [[ main | ↑1 ]]
+└── (unknown)
+    └── broken error
+`
+	assert.Equal(t, expected, Format(root, opts))
+	assert.Equal(t, 2, root.Children[1].Children[0].Depth)
+}
+
+// Test GroupByRemote separates hosts from repositories without a remote.
+func TestBuild_GroupByRemote(t *testing.T) {
+	opts := DefaultFormatOptions()
+	opts.GroupBy = GroupByRemote
+	opts.SortBy = models.SortByAhead
+	root := Build("/root", newGroupTestRepos(), opts)
+
+	require.Len(t, root.Children, 3)
+	assert.Equal(t, "github.com", root.Children[0].Name())
+	assert.Equal(t, []string{"svc/web", "svc/api"}, []string{
+		root.Children[0].Children[0].Name(), root.Children[0].Children[1].Name(),
+	})
+	assert.Equal(t, "(no remote)", root.Children[1].Name())
+	assert.Equal(t, "(unknown)", root.Children[2].Name())
+	assert.True(t, root.Children[2].IsLast)
+}
+
+// Test GroupByStatus orders categories from most to least urgent.
+func TestBuild_GroupByStatus(t *testing.T) {
+	opts := DefaultFormatOptions()
+	opts.GroupBy = GroupByStatus
+	root := Build("/root", newGroupTestRepos(), opts)
+
+	names := make([]string, 0, len(root.Children))
+	for _, group := range root.Children {
+		names = append(names, group.Name())
+	}
+
+	assert.Equal(t, []string{"error", "dirty", "unpushed"}, names)
+	assert.Len(t, root.Children[2].Children, 2)
+}
+
+// Test GroupByStatus puts in-progress operations right after errors.
+func TestBuild_GroupByStatusInProgress(t *testing.T) {
+	repos := append(newGroupTestRepos(), &models.Repository{
+		Path:      "/root/merging",
+		Name:      "merging",
+		GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, Operation: "MERGING"},
+	})
+
+	opts := DefaultFormatOptions()
+	opts.GroupBy = GroupByStatus
+	root := Build("/root", repos, opts)
+
+	require.Len(t, root.Children, 4)
+	assert.Equal(t, "in-progress", root.Children[1].Name())
+	assert.Equal(t, "merging", root.Children[1].Children[0].Name())
+}
+
+// Test GroupByStatus separates diverged branches and gone upstreams from other unpushed or behind branches.
+func TestBuild_GroupByStatusDivergedAndGone(t *testing.T) {
+	repos := append(newGroupTestRepos(),
+		&models.Repository{
+			Path:      "/root/forked",
+			Name:      "forked",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, Ahead: 2, Behind: 1},
+		},
+		&models.Repository{
+			Path:      "/root/merged",
+			Name:      "merged",
+			GitStatus: &models.GitStatus{Branch: "fix", HasRemote: true, Upstream: "origin/fix", UpstreamGone: true},
+		},
+	)
+
+	opts := DefaultFormatOptions()
+	opts.GroupBy = GroupByStatus
+	root := Build("/root", repos, opts)
+
+	names := make([]string, 0, len(root.Children))
+	for _, group := range root.Children {
+		names = append(names, group.Name())
+	}
+
+	assert.Equal(t, []string{"error", "dirty", "diverged", "unpushed", "gone"}, names)
+	assert.Equal(t, "forked", root.Children[2].Children[0].Name())
+	assert.Equal(t, "merged", root.Children[4].Children[0].Name())
+}
+
+// Test Build with SortBy orders the directory hierarchy by subtree values.
+func TestBuild_SortByAhead(t *testing.T) {
+	opts := DefaultFormatOptions()
+	opts.SortBy = models.SortByAhead
+	root := Build("/root", newGroupTestRepos(), opts)
+
+	require.Len(t, root.Children, 3)
+	assert.Equal(t, "svc", root.Children[0].Name())
+	assert.Equal(t, "web", root.Children[0].Children[0].Name())
+	assert.Equal(t, []string{"broken", "notes"}, []string{root.Children[1].Name(), root.Children[2].Name()})
+}
@@ -0,0 +1,136 @@
+package tree
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+//go:embed templates/report.html.tmpl
+var reportTemplateSource string
+
+// reportTemplate is the parsed HTML report template.
+//
+//nolint:gochecknoglobals // Parsed once at init; templates are safe for concurrent use.
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateSource))
+
+// htmlReport is the data passed to the HTML report template.
+type htmlReport struct {
+	RootPath    string
+	GeneratedAt string
+	Tree        *htmlNode
+	Scan        jsonScanStats
+	Fetch       *jsonFetch
+}
+
+// htmlNode is a tree node prepared for HTML rendering.
+type htmlNode struct {
+	Name        string
+	IsDirectory bool
+	Badges      []models.Indicator
+	Attention   bool
+	Flags       []string
+	Commit      string // Short hash and subject of the HEAD commit
+	CommitTitle string // Author and date of the HEAD commit, shown on hover
+	Errors      []htmlError
+	Children    []*htmlNode
+}
+
+// htmlError is a labeled error message shown below a repository.
+type htmlError struct {
+	Label   string
+	Message string
+}
+
+// FormatHTML generates a self-contained HTML report with a collapsible repository tree,
+// status badges matching GitStatus.Format, per-repository error details and the scan and
+// fetch summary. scanResult and batchResult may be nil.
+func FormatHTML(root *models.TreeNode, scanResult *models.ScanResult, batchResult *models.BatchResult) (string, error) {
+	report := htmlReport{
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Tree:        newHTMLNode(root),
+		Scan:        newJSONScanStats(scanResult),
+	}
+
+	if scanResult != nil {
+		report.RootPath = scanResult.RootPath
+	} else if root != nil && root.Repository != nil {
+		report.RootPath = root.Repository.Path
+	}
+
+	if batchResult != nil && batchResult.FetchStats != nil {
+		report.Fetch = newJSONFetch(batchResult.FetchStats)
+	}
+
+	var builder strings.Builder
+	if err := reportTemplate.Execute(&builder, report); err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+// shortHash abbreviates a commit hash to the seven characters git shows by default.
+func shortHash(hash string) string {
+	const shortHashLength = 7
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+
+	return hash
+}
+
+// newHTMLNode recursively converts a tree node and its children.
+func newHTMLNode(node *models.TreeNode) *htmlNode {
+	if node == nil || node.Repository == nil {
+		return nil
+	}
+
+	repo := node.Repository
+	hn := &htmlNode{
+		Name:        node.Name(),
+		IsDirectory: node.IsDirectory,
+	}
+
+	if !node.IsDirectory {
+		if repo.GitStatus != nil {
+			hn.Badges = repo.GitStatus.Indicators()
+			hn.Attention = !repo.GitStatus.IsStandardStatus()
+			if status := repo.GitStatus; status.LastCommitHash != "" {
+				hn.Commit = strings.TrimSpace(shortHash(status.LastCommitHash) + " " + status.LastCommitSubject)
+				hn.CommitTitle = status.LastCommitAuthor + ", " + status.LastCommitDate.Format(time.RFC3339)
+			}
+			if repo.GitStatus.Error != "" {
+				hn.Errors = append(hn.Errors, htmlError{Label: "Status error", Message: repo.GitStatus.Error})
+			}
+			if repo.GitStatus.FetchError != "" {
+				hn.Errors = append(hn.Errors, htmlError{Label: "Fetch error", Message: repo.GitStatus.FetchError})
+			}
+		}
+
+		if repo.Error != nil {
+			if repo.GitStatus == nil {
+				hn.Flags = append(hn.Flags, "error")
+			}
+			hn.Errors = append(hn.Errors, htmlError{Label: "Error", Message: repo.Error.Error()})
+		}
+		if repo.HasTimeout {
+			hn.Flags = append(hn.Flags, "timeout")
+		}
+		if repo.IsBare {
+			hn.Flags = append(hn.Flags, "bare")
+		}
+	}
+
+	for _, child := range node.Children {
+		if hc := newHTMLNode(child); hc != nil {
+			hn.Children = append(hn.Children, hc)
+		}
+	}
+
+	return hn
+}
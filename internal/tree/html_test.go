@@ -0,0 +1,83 @@
+package tree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test FormatHTML renders collapsible directories, status badges and the summary.
+func TestFormatHTML_RendersTreeAndSummary(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path: "/root/project1",
+			Name: "project1",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				LastCommitHash: "0123456789abcdef0123456789abcdef01234567", LastCommitSubject: "Fix <parser>",
+				LastCommitAuthor: "Ada", LastCommitDate: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Path:      "/root/nested/project2",
+			Name:      "project2",
+			GitStatus: &models.GitStatus{Branch: "feature", HasRemote: true, Ahead: 2, HasChanges: true},
+		},
+	}
+	scanResult := &models.ScanResult{RootPath: "/root", Repositories: repos, TotalScanned: 7, TotalRepos: 2}
+	batchResult := &models.BatchResult{
+		FetchStats: &models.FetchStats{TotalAttempted: 2, Successful: 1, Failed: 1, FailedRepos: []string{"/root/project1"}},
+	}
+
+	root := Build("/root", repos, nil)
+	output, err := FormatHTML(root, scanResult, batchResult)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "<!DOCTYPE html>")
+	assert.Contains(t, output, "<details open>", "directories should be collapsible")
+	assert.Contains(t, output, `<span class="dir">nested/</span>`)
+	assert.Contains(t, output, `<span class="badge gray">main</span>`)
+	assert.Contains(t, output, `<span class="badge yellow">feature</span>`)
+	assert.Contains(t, output, `<span class="badge green">↑2</span>`)
+	assert.Contains(t, output, `<span class="badge red">*</span>`)
+	assert.Contains(t, output, `<span class="commit" title="Ada, 2024-06-01T09:00:00Z">0123456 Fix &lt;parser&gt;</span>`)
+	assert.Contains(t, output, "7 folders")
+	assert.Contains(t, output, "2 attempted, 1 successful, 0 skipped, 0 fresh, 1 failed")
+	assert.Contains(t, output, "<li>/root/project1</li>")
+	assert.NotContains(t, output, "\x1b[", "HTML output should not contain terminal escape codes")
+}
+
+// Test FormatHTML shows per-repository error details and escapes them.
+func TestFormatHTML_ErrorDetailsEscaped(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path: "/root/broken",
+			Name: "broken",
+			GitStatus: &models.GitStatus{
+				Branch:     "N/A",
+				Error:      "failed to open <repo>",
+				FetchError: "auth & network failure",
+			},
+		},
+	}
+
+	root := Build("/root", repos, nil)
+	output, err := FormatHTML(root, nil, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "<dt>Status error</dt><dd>failed to open &lt;repo&gt;</dd>")
+	assert.Contains(t, output, "<dt>Fetch error</dt><dd>auth &amp; network failure</dd>")
+	assert.Contains(t, output, `<span class="badge red">fetch-err</span>`)
+}
+
+// Test FormatHTML handles an empty tree.
+func TestFormatHTML_EmptyTree(t *testing.T) {
+	root := Build("/root", nil, nil)
+	output, err := FormatHTML(root, nil, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "No repositories to show.")
+}
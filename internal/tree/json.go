@@ -0,0 +1,353 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/cli"
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// JSONSchemaVersion is the version of the JSON document layout produced by FormatJSON.
+// It is incremented whenever a field is removed or changes meaning; adding new fields
+// does not change the version.
+const JSONSchemaVersion = 1
+
+// jsonDocument is the top-level JSON document describing a complete scan.
+type jsonDocument struct {
+	SchemaVersion int           `json:"schema_version"`
+	RootPath      string        `json:"root_path"`
+	Scan          jsonScanStats `json:"scan"`
+	Status        jsonBatchInfo `json:"status"`
+	Fetch         *jsonFetch    `json:"fetch,omitempty"`
+	Tree          *jsonNode     `json:"tree"`
+}
+
+// jsonScanStats mirrors models.ScanResult statistics.
+type jsonScanStats struct {
+	TotalScanned int          `json:"total_scanned"`
+	TotalRepos   int          `json:"total_repos"`
+	DurationMS   int64        `json:"duration_ms"`
+	Errors       []*jsonError `json:"errors"`
+}
+
+// jsonBatchInfo mirrors models.BatchResult counters.
+type jsonBatchInfo struct {
+	SuccessCount int      `json:"success_count"`
+	FailureCount int      `json:"failure_count"`
+	FailedRepos  []string `json:"failed_repos"`
+}
+
+// jsonFetch mirrors models.FetchStats.
+type jsonFetch struct {
+	TotalAttempted   int      `json:"total_attempted"`
+	Successful       int      `json:"successful"`
+	Skipped          int      `json:"skipped"`
+	Fresh            int      `json:"fresh"`
+	Failed           int      `json:"failed"`
+	FailedRepos      []string `json:"failed_repos"`
+	UnreachableHosts []string `json:"unreachable_hosts"`
+}
+
+// jsonNode is a node of the repository tree.
+type jsonNode struct {
+	Name         string          `json:"name"`
+	RelativePath string          `json:"relative_path"`
+	IsDirectory  bool            `json:"is_directory"`
+	Repository   *jsonRepository `json:"repository,omitempty"`
+	Children     []*jsonNode     `json:"children"`
+}
+
+// jsonRepository mirrors models.Repository.
+type jsonRepository struct {
+	Path           string      `json:"path"`
+	Name           string      `json:"name"`
+	IsBare         bool        `json:"is_bare"`
+	IsSymlink      bool        `json:"is_symlink"`
+	IsWorktree     bool        `json:"is_worktree"`
+	WorktreeOf     string      `json:"worktree_of,omitempty"`
+	SubmoduleOf    string      `json:"submodule_of,omitempty"`
+	SubmoduleState string      `json:"submodule_state,omitempty"`
+	HasTimeout     bool        `json:"has_timeout"`
+	NeedsAttention bool        `json:"needs_attention"`
+	Error          *jsonError  `json:"error,omitempty"`
+	Status         *jsonStatus `json:"status,omitempty"`
+}
+
+// jsonStatus mirrors models.GitStatus.
+type jsonStatus struct {
+	Branch            string          `json:"branch"`
+	IsDetached        bool            `json:"is_detached"`
+	DefaultBranch     string          `json:"default_branch,omitempty"`
+	IsTrunk           bool            `json:"is_trunk"`
+	Operation         string          `json:"operation,omitempty"`
+	HasRemote         bool            `json:"has_remote"`
+	RemoteHost        string          `json:"remote_host,omitempty"`
+	Upstream          string          `json:"upstream,omitempty"`
+	UpstreamGone      bool            `json:"upstream_gone"`
+	SyncState         string          `json:"sync_state,omitempty"`
+	Ahead             int             `json:"ahead"`
+	Behind            int             `json:"behind"`
+	BaseBranch        string          `json:"base_branch,omitempty"`
+	BaseAhead         int             `json:"base_ahead"`
+	BaseBehind        int             `json:"base_behind"`
+	HasStashes        bool            `json:"has_stashes"`
+	StashCount        int             `json:"stash_count"`
+	Stashes           []jsonStash     `json:"stashes,omitempty"`
+	Submodules        []jsonSubmodule `json:"submodules,omitempty"`
+	Branches          []jsonBranch    `json:"branches,omitempty"`
+	HasChanges        bool            `json:"has_changes"`
+	Changes           jsonChanges     `json:"changes"`
+	LastCommitHash    string          `json:"last_commit_hash,omitempty"`
+	LastCommitSubject string          `json:"last_commit_subject,omitempty"`
+	LastCommitAuthor  string          `json:"last_commit_author,omitempty"`
+	LastCommitDate    *time.Time      `json:"last_commit_date,omitempty"`
+	IsStandard        bool            `json:"is_standard"`
+	Error             *jsonError      `json:"error,omitempty"`
+	FetchError        *jsonError      `json:"fetch_error,omitempty"`
+}
+
+// jsonChanges mirrors models.ChangeCounts.
+type jsonChanges struct {
+	Staged     int `json:"staged"`
+	Modified   int `json:"modified"`
+	Deleted    int `json:"deleted"`
+	Renamed    int `json:"renamed"`
+	Untracked  int `json:"untracked"`
+	Conflicted int `json:"conflicted"`
+}
+
+// jsonStash mirrors models.StashEntry.
+type jsonStash struct {
+	Index   int       `json:"index"`
+	Branch  string    `json:"branch,omitempty"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+}
+
+// jsonSubmodule mirrors models.SubmoduleStatus. The submodule's own status is reported
+// on its tree node.
+type jsonSubmodule struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	State    string `json:"state"`
+	Expected string `json:"expected,omitempty"`
+	Current  string `json:"current,omitempty"`
+}
+
+// jsonBranch mirrors models.BranchStatus.
+type jsonBranch struct {
+	Name           string     `json:"name"`
+	Upstream       string     `json:"upstream,omitempty"`
+	UpstreamGone   bool       `json:"upstream_gone"`
+	Unpushed       int        `json:"unpushed"`
+	LastCommitDate *time.Time `json:"last_commit_date,omitempty"`
+	IsHead         bool       `json:"is_head"`
+}
+
+// jsonError is a structured error value.
+type jsonError struct {
+	Message string `json:"message"`
+}
+
+// FormatJSON generates a versioned JSON document from a tree structure and the
+// scan and batch results it was built from. scanResult and batchResult may be nil.
+func FormatJSON(root *models.TreeNode, scanResult *models.ScanResult, batchResult *models.BatchResult) (string, error) {
+	doc := jsonDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Scan:          newJSONScanStats(scanResult),
+		Status:        newJSONBatchInfo(batchResult),
+		Tree:          newJSONNode(root),
+	}
+
+	if scanResult != nil {
+		doc.RootPath = scanResult.RootPath
+	} else if root != nil && root.Repository != nil {
+		doc.RootPath = root.Repository.Path
+	}
+
+	if batchResult != nil && batchResult.FetchStats != nil {
+		doc.Fetch = newJSONFetch(batchResult.FetchStats)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return string(data) + "\n", nil
+}
+
+// newJSONScanStats converts scan statistics, tolerating a nil result.
+func newJSONScanStats(scanResult *models.ScanResult) jsonScanStats {
+	stats := jsonScanStats{Errors: []*jsonError{}}
+	if scanResult == nil {
+		return stats
+	}
+
+	stats.TotalScanned = scanResult.TotalScanned
+	stats.TotalRepos = scanResult.TotalRepos
+	stats.DurationMS = scanResult.Duration.Milliseconds()
+	for _, err := range scanResult.Errors {
+		stats.Errors = append(stats.Errors, newJSONError(err.Error()))
+	}
+
+	return stats
+}
+
+// newJSONBatchInfo converts batch counters, tolerating a nil result.
+func newJSONBatchInfo(batchResult *models.BatchResult) jsonBatchInfo {
+	info := jsonBatchInfo{FailedRepos: []string{}}
+	if batchResult == nil {
+		return info
+	}
+
+	info.SuccessCount = batchResult.SuccessCount
+	info.FailureCount = batchResult.FailureCount
+	info.FailedRepos = append(info.FailedRepos, batchResult.FailedRepos...)
+
+	return info
+}
+
+// newJSONFetch converts fetch statistics.
+func newJSONFetch(stats *models.FetchStats) *jsonFetch {
+	return &jsonFetch{
+		TotalAttempted:   stats.TotalAttempted,
+		Successful:       stats.Successful,
+		Skipped:          stats.Skipped,
+		Fresh:            stats.Fresh,
+		Failed:           stats.Failed,
+		FailedRepos:      append([]string{}, stats.FailedRepos...),
+		UnreachableHosts: append([]string{}, stats.UnreachableHosts...),
+	}
+}
+
+// newJSONNode recursively converts a tree node and its children.
+func newJSONNode(node *models.TreeNode) *jsonNode {
+	if node == nil {
+		return nil
+	}
+
+	jn := &jsonNode{
+		RelativePath: filepath.ToSlash(node.RelativePath),
+		IsDirectory:  node.IsDirectory,
+		Children:     make([]*jsonNode, 0, len(node.Children)),
+	}
+
+	if node.Repository != nil {
+		jn.Name = node.Name()
+		if !node.IsDirectory {
+			jn.Repository = newJSONRepository(node.Repository)
+		}
+	}
+
+	for _, child := range node.Children {
+		jn.Children = append(jn.Children, newJSONNode(child))
+	}
+
+	return jn
+}
+
+// newJSONRepository converts a repository and its Git status.
+func newJSONRepository(repo *models.Repository) *jsonRepository {
+	jr := &jsonRepository{
+		Path:           repo.Path,
+		Name:           repo.Name,
+		IsBare:         repo.IsBare,
+		IsWorktree:     repo.IsWorktree,
+		WorktreeOf:     repo.WorktreeOf,
+		SubmoduleOf:    repo.SubmoduleOf,
+		SubmoduleState: string(repo.SubmoduleState),
+		IsSymlink:      repo.IsSymlink,
+		HasTimeout:     repo.HasTimeout,
+		NeedsAttention: !cli.IsClean(repo),
+	}
+
+	if repo.Error != nil {
+		jr.Error = newJSONError(repo.Error.Error())
+	}
+
+	if repo.GitStatus != nil {
+		jr.Status = newJSONStatus(repo.GitStatus)
+	}
+
+	return jr
+}
+
+// newJSONStatus converts a Git status.
+func newJSONStatus(status *models.GitStatus) *jsonStatus {
+	js := &jsonStatus{
+		Branch:            status.Branch,
+		IsDetached:        status.IsDetached,
+		DefaultBranch:     status.DefaultBranch,
+		IsTrunk:           status.IsTrunk(),
+		Operation:         status.Operation,
+		HasRemote:         status.HasRemote,
+		RemoteHost:        status.RemoteHost,
+		Upstream:          status.Upstream,
+		UpstreamGone:      status.UpstreamGone,
+		SyncState:         string(status.SyncState()),
+		Ahead:             status.Ahead,
+		Behind:            status.Behind,
+		BaseBranch:        status.BaseBranch,
+		BaseAhead:         status.BaseAhead,
+		BaseBehind:        status.BaseBehind,
+		HasStashes:        status.HasStashes,
+		StashCount:        status.StashCount,
+		HasChanges:        status.HasChanges,
+		Changes:           jsonChanges(status.Changes),
+		LastCommitHash:    status.LastCommitHash,
+		LastCommitSubject: status.LastCommitSubject,
+		LastCommitAuthor:  status.LastCommitAuthor,
+		IsStandard:        status.IsStandardStatus(),
+		Error:             newJSONError(status.Error),
+		FetchError:        newJSONError(status.FetchError),
+	}
+
+	if !status.LastCommitDate.IsZero() {
+		date := status.LastCommitDate
+		js.LastCommitDate = &date
+	}
+
+	for _, stash := range status.Stashes {
+		js.Stashes = append(js.Stashes, jsonStash(stash))
+	}
+
+	for _, sub := range status.Submodules {
+		js.Submodules = append(js.Submodules, jsonSubmodule{
+			Name:     sub.Name,
+			Path:     sub.Path,
+			State:    string(sub.State),
+			Expected: sub.Expected,
+			Current:  sub.Current,
+		})
+	}
+
+	for _, branch := range status.Branches {
+		jb := jsonBranch{
+			Name:         branch.Name,
+			Upstream:     branch.Upstream,
+			UpstreamGone: branch.UpstreamGone,
+			Unpushed:     branch.Unpushed,
+			IsHead:       branch.IsHead,
+		}
+		if !branch.LastCommitDate.IsZero() {
+			date := branch.LastCommitDate
+			jb.LastCommitDate = &date
+		}
+		js.Branches = append(js.Branches, jb)
+	}
+
+	return js
+}
+
+// newJSONError wraps a non-empty error message; it returns nil for an empty message.
+func newJSONError(message string) *jsonError {
+	if message == "" {
+		return nil
+	}
+
+	return &jsonError{Message: message}
+}
@@ -0,0 +1,258 @@
+package tree
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test FormatJSON produces a versioned document that preserves the tree hierarchy.
+func TestFormatJSON_PreservesHierarchy(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path:      "/root/project1",
+			Name:      "project1",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, Ahead: 2, Behind: 1},
+		},
+		{
+			Path:      "/root/nested/project2",
+			Name:      "project2",
+			GitStatus: &models.GitStatus{Branch: "develop", HasChanges: true},
+		},
+	}
+	scanResult := &models.ScanResult{
+		RootPath:     "/root",
+		Repositories: repos,
+		TotalScanned: 4,
+		TotalRepos:   2,
+		Duration:     1500 * time.Millisecond,
+	}
+
+	root := Build("/root", repos, nil)
+	output, err := FormatJSON(root, scanResult, &models.BatchResult{SuccessCount: 2})
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal([]byte(output), &doc))
+
+	assert.InDelta(t, float64(JSONSchemaVersion), doc["schema_version"], 0)
+	assert.Equal(t, "/root", doc["root_path"])
+	assert.NotContains(t, doc, "fetch", "fetch summary should be omitted when fetch is disabled")
+
+	scan, ok := doc["scan"].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 4, scan["total_scanned"], 0)
+	assert.InDelta(t, 1500, scan["duration_ms"], 0)
+
+	treeNode, ok := doc["tree"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, true, treeNode["is_directory"])
+	children, ok := treeNode["children"].([]any)
+	require.True(t, ok)
+	require.Len(t, children, 2)
+
+	nested, ok := children[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "nested", nested["relative_path"])
+	assert.NotContains(t, nested, "repository", "intermediate directories should not carry a repository")
+
+	nestedChildren, ok := nested["children"].([]any)
+	require.True(t, ok)
+	require.Len(t, nestedChildren, 1)
+	project2, ok := nestedChildren[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "nested/project2", project2["relative_path"])
+}
+
+// Test FormatJSON includes the HEAD commit metadata.
+func TestFormatJSON_LastCommit(t *testing.T) {
+	date := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	repos := []*models.Repository{
+		{
+			Path: "/root/app",
+			Name: "app",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				LastCommitHash: "0123456789abcdef0123456789abcdef01234567", LastCommitSubject: "Fix parser",
+				LastCommitAuthor: "Ada", LastCommitDate: date,
+			},
+		},
+	}
+
+	output, err := FormatJSON(Build("/root", repos, nil), nil, nil)
+	require.NoError(t, err)
+
+	var doc struct {
+		Tree struct {
+			Children []struct {
+				Repository struct {
+					Status map[string]any `json:"status"`
+				} `json:"repository"`
+			} `json:"children"`
+		} `json:"tree"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &doc))
+	require.Len(t, doc.Tree.Children, 1)
+
+	status := doc.Tree.Children[0].Repository.Status
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", status["last_commit_hash"])
+	assert.Equal(t, "Fix parser", status["last_commit_subject"])
+	assert.Equal(t, "Ada", status["last_commit_author"])
+	assert.Equal(t, "2024-06-01T09:00:00Z", status["last_commit_date"])
+}
+
+// Test FormatJSON includes the local branches of a repository.
+func TestFormatJSON_Branches(t *testing.T) {
+	date := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	repos := []*models.Repository{
+		{
+			Path: "/root/app",
+			Name: "app",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				Branches: []models.BranchStatus{
+					{Name: "main", Upstream: "origin/main", LastCommitDate: date, IsHead: true},
+					{Name: "spike", Unpushed: 3},
+				},
+			},
+		},
+	}
+
+	output, err := FormatJSON(Build("/root", repos, nil), nil, nil)
+	require.NoError(t, err)
+
+	var doc struct {
+		Tree struct {
+			Children []struct {
+				Repository struct {
+					Status struct {
+						Branches []map[string]any `json:"branches"`
+					} `json:"status"`
+				} `json:"repository"`
+			} `json:"children"`
+		} `json:"tree"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &doc))
+	require.Len(t, doc.Tree.Children, 1)
+
+	branches := doc.Tree.Children[0].Repository.Status.Branches
+	require.Len(t, branches, 2)
+	assert.Equal(t, map[string]any{
+		"name": "main", "upstream": "origin/main", "upstream_gone": false, "unpushed": float64(0),
+		"last_commit_date": "2025-06-15T12:00:00Z", "is_head": true,
+	}, branches[0])
+	assert.Equal(t, map[string]any{"name": "spike", "upstream_gone": false, "unpushed": float64(3), "is_head": false}, branches[1])
+}
+
+// Test FormatJSON emits errors as structured objects.
+func TestFormatJSON_StructuredErrors(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path:  "/root/broken",
+			Name:  "broken",
+			Error: ErrCorruptedRepository,
+			GitStatus: &models.GitStatus{
+				Branch:     "N/A",
+				Error:      "failed to get HEAD",
+				FetchError: "fetch failed after retries",
+			},
+		},
+	}
+	batchResult := &models.BatchResult{
+		FailureCount: 1,
+		FailedRepos:  []string{"/root/broken"},
+		FetchStats: &models.FetchStats{
+			TotalAttempted:   1,
+			Failed:           1,
+			Fresh:            2,
+			FailedRepos:      []string{"/root/broken"},
+			UnreachableHosts: []string{"git.example.com"},
+		},
+	}
+
+	root := Build("/root", repos, nil)
+	output, err := FormatJSON(root, nil, batchResult)
+	require.NoError(t, err)
+
+	var doc struct {
+		Fetch struct {
+			Failed           int      `json:"failed"`
+			Fresh            int      `json:"fresh"`
+			UnreachableHosts []string `json:"unreachable_hosts"`
+		} `json:"fetch"`
+		Tree struct {
+			Children []struct {
+				Repository struct {
+					Error struct {
+						Message string `json:"message"`
+					} `json:"error"`
+					Status struct {
+						IsStandard bool `json:"is_standard"`
+						Error      struct {
+							Message string `json:"message"`
+						} `json:"error"`
+						FetchError struct {
+							Message string `json:"message"`
+						} `json:"fetch_error"`
+					} `json:"status"`
+				} `json:"repository"`
+			} `json:"children"`
+		} `json:"tree"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &doc))
+
+	assert.Equal(t, 1, doc.Fetch.Failed)
+	assert.Equal(t, 2, doc.Fetch.Fresh)
+	assert.Equal(t, []string{"git.example.com"}, doc.Fetch.UnreachableHosts)
+	require.Len(t, doc.Tree.Children, 1)
+	repo := doc.Tree.Children[0].Repository
+	assert.Equal(t, "corrupted repository", repo.Error.Message)
+	assert.Equal(t, "failed to get HEAD", repo.Status.Error.Message)
+	assert.Equal(t, "fetch failed after retries", repo.Status.FetchError.Message)
+	assert.False(t, repo.Status.IsStandard)
+}
+
+// Test every repository carries needs_attention, matching the filter applied to the tree.
+func TestFormatJSON_NeedsAttention(t *testing.T) {
+	repos := []*models.Repository{
+		{Path: "/root/clean", Name: "clean", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true}},
+		{Path: "/root/dirty", Name: "dirty", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, HasChanges: true}},
+		{Path: "/root/unknown", Name: "unknown"},
+	}
+
+	output, err := FormatJSON(Build("/root", repos, nil), nil, nil)
+	require.NoError(t, err)
+
+	var doc struct {
+		Tree struct {
+			Children []struct {
+				Name       string `json:"name"`
+				Repository struct {
+					NeedsAttention bool `json:"needs_attention"`
+				} `json:"repository"`
+			} `json:"children"`
+		} `json:"tree"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &doc))
+
+	attention := make(map[string]bool)
+	for _, child := range doc.Tree.Children {
+		attention[child.Name] = child.Repository.NeedsAttention
+	}
+	assert.Equal(t, map[string]bool{"clean": false, "dirty": true, "unknown": true}, attention)
+}
+
+// Test FormatJSON handles an empty tree and nil results.
+func TestFormatJSON_EmptyTree(t *testing.T) {
+	root := Build("/root", nil, nil)
+	output, err := FormatJSON(root, nil, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, output, `"root_path": "/root"`)
+	assert.Contains(t, output, `"children": []`)
+	assert.Contains(t, output, `"errors": []`)
+}
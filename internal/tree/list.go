@@ -0,0 +1,38 @@
+package tree
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// FormatList renders the relative path of each repository on its own line, sorted,
+// without color, connectors or status, so the output can be piped into tools like xargs.
+// Paths use "/" separators and are relative to rootPath; the root itself is ".".
+func FormatList(rootPath string, repos []*models.Repository) string {
+	paths := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		if repo == nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			relPath = repo.Path
+		}
+
+		paths = append(paths, filepath.ToSlash(relPath))
+	}
+
+	sort.Strings(paths)
+
+	var builder strings.Builder
+	for _, path := range paths {
+		builder.WriteString(path)
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,26 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test FormatList prints sorted relative paths without status or decoration.
+func TestFormatList(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{Path: "/root/src/github.com/acme/api", Name: "api", GitStatus: &models.GitStatus{Branch: "feature", HasChanges: true}},
+		{Path: "/root/dotfiles", Name: "dotfiles", Error: ErrCorruptedRepository},
+		{Path: "/root", Name: "root"},
+		nil,
+	}
+
+	assert.Equal(t, ".\ndotfiles\nsrc/github.com/acme/api\n", FormatList("/root", repos))
+	assert.Empty(t, FormatList("/root", nil))
+}
@@ -0,0 +1,96 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// Record types emitted by NDJSONWriter.
+const (
+	ndjsonTypeRepository = "repository"
+	ndjsonTypeSummary    = "summary"
+)
+
+// ndjsonRepositoryRecord is a single repository line in the NDJSON stream.
+type ndjsonRepositoryRecord struct {
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	RelativePath  string          `json:"relative_path"`
+	Repository    *jsonRepository `json:"repository"`
+}
+
+// ndjsonSummaryRecord is the final line in the NDJSON stream.
+type ndjsonSummaryRecord struct {
+	Type          string        `json:"type"`
+	SchemaVersion int           `json:"schema_version"`
+	RootPath      string        `json:"root_path"`
+	Scan          jsonScanStats `json:"scan"`
+	Status        jsonBatchInfo `json:"status"`
+	Fetch         *jsonFetch    `json:"fetch,omitempty"`
+}
+
+// NDJSONWriter streams repository results as newline-delimited JSON, one object per line.
+// Records share the field layout of FormatJSON and carry the same schema version.
+type NDJSONWriter struct {
+	rootPath string
+	encoder  *json.Encoder
+}
+
+// NewNDJSONWriter creates a writer that emits records to w.
+// Relative paths in repository records are computed against rootPath.
+func NewNDJSONWriter(w io.Writer, rootPath string) *NDJSONWriter {
+	return &NDJSONWriter{
+		rootPath: rootPath,
+		encoder:  json.NewEncoder(w),
+	}
+}
+
+// WriteRepository writes one repository record.
+func (n *NDJSONWriter) WriteRepository(repo *models.Repository) error {
+	if repo == nil {
+		return nil
+	}
+
+	relPath, err := filepath.Rel(n.rootPath, repo.Path)
+	if err != nil {
+		relPath = repo.Path
+	}
+
+	return n.encode(ndjsonRepositoryRecord{
+		Type:          ndjsonTypeRepository,
+		SchemaVersion: JSONSchemaVersion,
+		RelativePath:  filepath.ToSlash(relPath),
+		Repository:    newJSONRepository(repo),
+	})
+}
+
+// WriteSummary writes the final summary record with scan and fetch statistics.
+// scanResult and batchResult may be nil.
+func (n *NDJSONWriter) WriteSummary(scanResult *models.ScanResult, batchResult *models.BatchResult) error {
+	record := ndjsonSummaryRecord{
+		Type:          ndjsonTypeSummary,
+		SchemaVersion: JSONSchemaVersion,
+		RootPath:      n.rootPath,
+		Scan:          newJSONScanStats(scanResult),
+		Status:        newJSONBatchInfo(batchResult),
+	}
+
+	if batchResult != nil && batchResult.FetchStats != nil {
+		record.Fetch = newJSONFetch(batchResult.FetchStats)
+	}
+
+	return n.encode(record)
+}
+
+// encode writes a single record followed by a newline.
+func (n *NDJSONWriter) encode(record any) error {
+	if err := n.encoder.Encode(record); err != nil {
+		return fmt.Errorf("failed to encode NDJSON record: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package tree
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test NDJSONWriter emits one JSON object per line with a trailing summary record.
+func TestNDJSONWriter_RecordsPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf, "/root")
+
+	require.NoError(t, writer.WriteRepository(&models.Repository{
+		Path:      "/root/a/project1",
+		Name:      "project1",
+		GitStatus: &models.GitStatus{Branch: "feature", HasRemote: true, Ahead: 3},
+	}))
+	require.NoError(t, writer.WriteRepository(&models.Repository{
+		Path:      "/root/project2",
+		Name:      "project2",
+		GitStatus: &models.GitStatus{Branch: "main", FetchError: "fetch failed"},
+	}))
+	require.NoError(t, writer.WriteSummary(
+		&models.ScanResult{RootPath: "/root", TotalScanned: 5, TotalRepos: 2},
+		&models.BatchResult{SuccessCount: 2, FetchStats: &models.FetchStats{TotalAttempted: 2, Successful: 1, Failed: 1}},
+	))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "repository", first["type"])
+	assert.Equal(t, "a/project1", first["relative_path"])
+
+	var second struct {
+		Repository struct {
+			Status struct {
+				FetchError struct {
+					Message string `json:"message"`
+				} `json:"fetch_error"`
+			} `json:"status"`
+		} `json:"repository"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "fetch failed", second.Repository.Status.FetchError.Message)
+
+	var summary struct {
+		Type string `json:"type"`
+		Scan struct {
+			TotalScanned int `json:"total_scanned"`
+		} `json:"scan"`
+		Fetch struct {
+			Failed int `json:"failed"`
+		} `json:"fetch"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &summary))
+	assert.Equal(t, "summary", summary.Type)
+	assert.Equal(t, 5, summary.Scan.TotalScanned)
+	assert.Equal(t, 1, summary.Fetch.Failed)
+}
+
+// Test NDJSONWriter ignores nil repositories.
+func TestNDJSONWriter_NilRepository(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewNDJSONWriter(&buf, "/root")
+
+	require.NoError(t, writer.WriteRepository(nil))
+	assert.Empty(t, buf.String())
+}
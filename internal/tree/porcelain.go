@@ -0,0 +1,204 @@
+package tree
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// PorcelainVersion is the version token that starts every porcelain line.
+// The v1 field layout never changes; an incompatible layout gets a new version token.
+const PorcelainVersion = "v1"
+
+// Porcelain field values.
+const (
+	porcelainNone     = "-"
+	porcelainDetached = "(detached)"
+	porcelainUnknown  = "(unknown)"
+	porcelainDirty    = "*"
+)
+
+// Porcelain change flags, written in this order in the changes field.
+const (
+	porcelainStaged     = "S"
+	porcelainModified   = "M"
+	porcelainDeleted    = "D"
+	porcelainRenamed    = "R"
+	porcelainUntracked  = "?"
+	porcelainConflicted = "U"
+)
+
+// Porcelain error codes, joined with commas in the errors field.
+const (
+	porcelainErrRepository = "repo"
+	porcelainErrStatus     = "status"
+	porcelainErrTimeout    = "timeout"
+	porcelainErrFetch      = "fetch"
+)
+
+// FormatPorcelain renders a stable, script-friendly listing with one line per repository,
+// sorted by path. It never contains color or tree connectors. Each line has nine
+// space-separated fields:
+//
+//	v1 <path> <branch> <upstream> <ahead> <behind> <stash> <changes> <errors>
+//
+// The fields are:
+//   - path: path relative to rootPath using "/" separators
+//   - branch: current branch, "(detached)" for a detached HEAD, "(unknown)" if it could not be read
+//   - upstream: remote tracking branch such as "origin/main", or "-" if none or gone
+//   - ahead, behind: commit counts relative to upstream, or "-" if there is no upstream
+//   - stash: "1" if the repository has stashes, otherwise "0"
+//   - changes: "-" for a clean worktree, otherwise one or more flag characters: S staged, M modified,
+//     D deleted, R renamed, ? untracked, U conflicted, or "*" if the kind of change is unknown;
+//     consumers must treat any value other than "-" as dirty
+//   - errors: "-", or a comma-separated list of codes: repo, status, timeout, fetch
+//
+// Fields containing spaces, quotes, backslashes or non-printable characters are written as
+// double-quoted Go/C-style string literals, similar to git's core.quotePath.
+func FormatPorcelain(rootPath string, repos []*models.Repository) string {
+	type entry struct {
+		relPath string
+		repo    *models.Repository
+	}
+
+	entries := make([]entry, 0, len(repos))
+	for _, repo := range repos {
+		if repo == nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			relPath = repo.Path
+		}
+
+		entries = append(entries, entry{relPath: filepath.ToSlash(relPath), repo: repo})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].relPath < entries[j].relPath
+	})
+
+	var builder strings.Builder
+	for _, e := range entries {
+		builder.WriteString(porcelainLine(e.relPath, e.repo))
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// porcelainLine builds the porcelain line for a single repository.
+func porcelainLine(relPath string, repo *models.Repository) string {
+	branch := porcelainUnknown
+	upstream := porcelainNone
+	ahead := porcelainNone
+	behind := porcelainNone
+	stash := "0"
+	changes := porcelainNone
+
+	var errorCodes []string
+	if repo.Error != nil {
+		errorCodes = append(errorCodes, porcelainErrRepository)
+	}
+
+	if status := repo.GitStatus; status != nil {
+		switch {
+		case status.IsDetached:
+			branch = porcelainDetached
+		case status.Branch != "" && status.Branch != "N/A":
+			branch = status.Branch
+		}
+
+		// A gone upstream has no remote tracking branch to compare with
+		if status.Upstream != "" && !status.UpstreamGone {
+			upstream = status.Upstream
+			ahead = strconv.Itoa(status.Ahead)
+			behind = strconv.Itoa(status.Behind)
+		}
+
+		if status.HasStashes {
+			stash = "1"
+		}
+
+		if status.HasChanges {
+			changes = porcelainChanges(status.Changes)
+		}
+
+		if status.Error != "" {
+			errorCodes = append(errorCodes, porcelainErrStatus)
+		}
+		if repo.HasTimeout {
+			errorCodes = append(errorCodes, porcelainErrTimeout)
+		}
+		if status.FetchError != "" {
+			errorCodes = append(errorCodes, porcelainErrFetch)
+		}
+	}
+
+	errorsField := porcelainNone
+	if len(errorCodes) > 0 {
+		errorsField = strings.Join(errorCodes, ",")
+	}
+
+	fields := []string{
+		PorcelainVersion,
+		quotePorcelainField(relPath),
+		quotePorcelainField(branch),
+		quotePorcelainField(upstream),
+		ahead,
+		behind,
+		stash,
+		changes,
+		errorsField,
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// porcelainChanges returns the change flags for a dirty worktree.
+func porcelainChanges(counts models.ChangeCounts) string {
+	flags := []struct {
+		count int
+		flag  string
+	}{
+		{counts.Staged, porcelainStaged},
+		{counts.Modified, porcelainModified},
+		{counts.Deleted, porcelainDeleted},
+		{counts.Renamed, porcelainRenamed},
+		{counts.Untracked, porcelainUntracked},
+		{counts.Conflicted, porcelainConflicted},
+	}
+
+	var builder strings.Builder
+	for _, f := range flags {
+		if f.count > 0 {
+			builder.WriteString(f.flag)
+		}
+	}
+
+	if builder.Len() == 0 {
+		return porcelainDirty
+	}
+
+	return builder.String()
+}
+
+// quotePorcelainField quotes a field if it would otherwise break space-separated parsing.
+func quotePorcelainField(value string) string {
+	if value == "" {
+		return strconv.Quote(value)
+	}
+
+	for _, r := range value {
+		if r == ' ' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return strconv.Quote(value)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,94 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test FormatPorcelain renders fixed v1 fields, one sorted line per repository.
+func TestFormatPorcelain_Fields(t *testing.T) {
+	// Porcelain output must never be colored, even when colors are enabled
+	origNoColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{
+			Path: "/root/zeta",
+			Name: "zeta",
+			GitStatus: &models.GitStatus{
+				Branch:     "feature",
+				HasRemote:  true,
+				Upstream:   "origin/feature",
+				Ahead:      2,
+				Behind:     1,
+				HasStashes: true,
+				HasChanges: true,
+				FetchError: "fetch failed",
+			},
+		},
+		{
+			Path:      "/root/alpha",
+			Name:      "alpha",
+			GitStatus: &models.GitStatus{Branch: "DETACHED", IsDetached: true},
+		},
+		{
+			Path:      "/root/nested/broken",
+			Name:      "broken",
+			Error:     ErrCorruptedRepository,
+			GitStatus: &models.GitStatus{Branch: "N/A", Error: "failed to open repository"},
+		},
+	}
+
+	output := FormatPorcelain("/root", repos)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, "v1 alpha (detached) - - - 0 - -", lines[0])
+	assert.Equal(t, "v1 nested/broken (unknown) - - - 0 - repo,status", lines[1])
+	assert.Equal(t, "v1 zeta feature origin/feature 2 1 1 * fetch", lines[2])
+	assert.NotContains(t, output, "\x1b[")
+	assert.NotContains(t, output, "──")
+}
+
+// Test FormatPorcelain quotes fields that would break space-separated parsing.
+func TestFormatPorcelain_QuotesFields(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path:      "/root/my project",
+			Name:      "my project",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, Upstream: "origin/main"},
+		},
+	}
+
+	output := FormatPorcelain("/root", repos)
+
+	assert.Equal(t, "v1 \"my project\" main origin/main 0 0 0 - -\n", output)
+}
+
+// Test FormatPorcelain with no repositories produces no output.
+func TestFormatPorcelain_Empty(t *testing.T) {
+	assert.Empty(t, FormatPorcelain("/root", nil))
+}
+
+// Test FormatPorcelain writes one flag per change category in a fixed order.
+func TestFormatPorcelain_ChangeFlags(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path: "/root/repo",
+			Name: "repo",
+			GitStatus: &models.GitStatus{
+				Branch:     "main",
+				HasChanges: true,
+				Changes:    models.ChangeCounts{Conflicted: 1, Untracked: 4, Modified: 2, Staged: 1},
+			},
+		},
+	}
+
+	assert.Equal(t, "v1 repo main - - - 0 SM?U -\n", FormatPorcelain("/root", repos))
+}
@@ -0,0 +1,75 @@
+package tree
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// stashDateLayout is the date format of the stash list.
+const stashDateLayout = "2006-01-02 15:04"
+
+// stashRow is one stash of the stash list.
+type stashRow struct {
+	path  string
+	stash models.StashEntry
+}
+
+// FormatStashes renders every stash of the given repositories as aligned columns:
+// repository path, stash@{N}, branch, creation date with its age relative to now, and message.
+// Rows are sorted by path and then by stash index. An empty string is returned when no
+// repository has stashes.
+func FormatStashes(rootPath string, repos []*models.Repository, now time.Time) string {
+	var rows []stashRow
+	for _, repo := range repos {
+		if repo == nil || repo.GitStatus == nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			relPath = repo.Path
+		}
+
+		for _, stash := range repo.GitStatus.Stashes {
+			rows = append(rows, stashRow{path: filepath.ToSlash(relPath), stash: stash})
+		}
+	}
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].path != rows[j].path {
+			return rows[i].path < rows[j].path
+		}
+
+		return rows[i].stash.Index < rows[j].stash.Index
+	})
+
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, tableColumnGap, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "PATH\tSTASH\tBRANCH\tDATE\tMESSAGE")
+	for _, row := range rows {
+		branch := row.stash.Branch
+		if branch == "" {
+			branch = "-"
+		}
+
+		date := "-"
+		if !row.stash.Date.IsZero() {
+			date = fmt.Sprintf("%s (%s)", row.stash.Date.Format(stashDateLayout), formatAge(now.Sub(row.stash.Date)))
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\tstash@{%d}\t%s\t%s\t%s\n", row.path, row.stash.Index, branch, date, row.stash.Message)
+	}
+	_ = writer.Flush()
+
+	return builder.String()
+}
@@ -0,0 +1,47 @@
+package tree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test FormatStashes lists every stash sorted by path and index with aligned columns.
+func TestFormatStashes(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	repos := []*models.Repository{
+		{
+			Path: "/root/web",
+			Name: "web",
+			GitStatus: &models.GitStatus{Branch: "main", HasStashes: true, StashCount: 2, Stashes: []models.StashEntry{
+				{Index: 1, Branch: "main", Message: "WIP on main: abc1234 Old work", Date: now.Add(-90 * 24 * time.Hour)},
+				{Index: 0, Branch: "feature", Message: "On feature: parser", Date: now.Add(-3 * time.Hour)},
+			}},
+		},
+		{
+			Path: "/root/api",
+			Name: "api",
+			GitStatus: &models.GitStatus{Branch: "main", HasStashes: true, StashCount: 1, Stashes: []models.StashEntry{
+				{Index: 0, Message: "autostash"},
+			}},
+		},
+		{Path: "/root/clean", Name: "clean", GitStatus: &models.GitStatus{Branch: "main"}},
+		{Path: "/root/broken", Name: "broken", Error: ErrCorruptedRepository},
+	}
+
+	expected := "PATH  STASH      BRANCH   DATE                    MESSAGE\n" +
+		"api   stash@{0}  -        -                       autostash\n" +
+		"web   stash@{0}  feature  2024-06-01 09:00 (3h)   On feature: parser\n" +
+		"web   stash@{1}  main     2024-03-03 12:00 (3mo)  WIP on main: abc1234 Old work\n"
+	assert.Equal(t, expected, FormatStashes("/root", repos, now))
+}
+
+// Test FormatStashes returns an empty string when no repository has stashes.
+func TestFormatStashes_None(t *testing.T) {
+	repos := []*models.Repository{{Path: "/root/clean", Name: "clean", GitStatus: &models.GitStatus{Branch: "main"}}}
+
+	assert.Empty(t, FormatStashes("/root", repos, time.Now()))
+	assert.Empty(t, FormatStashes("/root", nil, time.Now()))
+}
@@ -0,0 +1,208 @@
+package tree
+
+import (
+	"encoding/csv"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// tableHeaders are the column names shared by the tabular renderers.
+//
+//nolint:gochecknoglobals // Read-only column definition.
+var tableHeaders = []string{
+	"path", "branch", "detached", "operation", "remote", "remote_host", "ahead", "behind", "sync_state", "stashes", "changes",
+	"staged", "modified", "deleted", "renamed", "untracked", "conflicted", "error", "fetch_error",
+	"last_commit_hash", "last_commit_subject", "last_commit_author", "last_commit_date",
+}
+
+// tableRow is one repository flattened into table columns.
+type tableRow struct {
+	Path       string
+	Branch     string
+	Detached   bool
+	Operation  string
+	Remote     bool
+	RemoteHost string // Host of the primary remote, empty if none or a local path
+	Ahead      int
+	Behind     int
+	SyncState  models.SyncState
+	Stashes    int
+	Changes    bool
+	Counts     models.ChangeCounts
+	Error      string
+	FetchError string
+	// Last commit metadata
+	LastCommitHash    string
+	LastCommitSubject string
+	LastCommitAuthor  string
+	LastCommitDate    time.Time
+}
+
+// values returns the row cells in tableHeaders order.
+func (r tableRow) values() []string {
+	return []string{
+		r.Path,
+		r.Branch,
+		strconv.FormatBool(r.Detached),
+		r.Operation,
+		strconv.FormatBool(r.Remote),
+		r.RemoteHost,
+		strconv.Itoa(r.Ahead),
+		strconv.Itoa(r.Behind),
+		string(r.SyncState),
+		strconv.Itoa(r.Stashes),
+		strconv.FormatBool(r.Changes),
+		strconv.Itoa(r.Counts.Staged),
+		strconv.Itoa(r.Counts.Modified),
+		strconv.Itoa(r.Counts.Deleted),
+		strconv.Itoa(r.Counts.Renamed),
+		strconv.Itoa(r.Counts.Untracked),
+		strconv.Itoa(r.Counts.Conflicted),
+		r.Error,
+		r.FetchError,
+		r.LastCommitHash,
+		r.LastCommitSubject,
+		r.LastCommitAuthor,
+		formatTableDate(r.LastCommitDate),
+	}
+}
+
+// formatTableDate renders a date as RFC 3339, or "" for a zero time.
+func formatTableDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// buildTableRows flattens repositories into rows sorted by relative path.
+func buildTableRows(rootPath string, repos []*models.Repository) []tableRow {
+	rows := make([]tableRow, 0, len(repos))
+
+	for _, repo := range repos {
+		if repo == nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(rootPath, repo.Path)
+		if err != nil {
+			relPath = repo.Path
+		}
+
+		row := tableRow{Path: filepath.ToSlash(relPath)}
+		if repo.Error != nil {
+			row.Error = repo.Error.Error()
+		}
+
+		if status := repo.GitStatus; status != nil {
+			row.Branch = status.Branch
+			row.Detached = status.IsDetached
+			row.Operation = status.Operation
+			row.Remote = status.HasRemote
+			row.RemoteHost = status.RemoteHost
+			row.Ahead = status.Ahead
+			row.Behind = status.Behind
+			row.SyncState = status.SyncState()
+			row.Stashes = stashCount(status)
+			row.Changes = status.HasChanges
+			row.Counts = status.Changes
+			row.FetchError = status.FetchError
+			row.LastCommitHash = status.LastCommitHash
+			row.LastCommitSubject = status.LastCommitSubject
+			row.LastCommitAuthor = status.LastCommitAuthor
+			row.LastCommitDate = status.LastCommitDate
+			if status.Error != "" {
+				row.Error = status.Error
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Path < rows[j].Path
+	})
+
+	return rows
+}
+
+// FormatCSV renders one CSV row per repository, preceded by a header row.
+// Paths are relative to rootPath.
+func FormatCSV(rootPath string, repos []*models.Repository) (string, error) {
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write(tableHeaders); err != nil {
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, row := range buildTableRows(rootPath, repos) {
+		if err := writer.Write(row.values()); err != nil {
+			return "", fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+// FormatMarkdown renders a GitHub-flavored Markdown table with one row per repository.
+// Paths are relative to rootPath.
+func FormatMarkdown(rootPath string, repos []*models.Repository) string {
+	var builder strings.Builder
+
+	writeMarkdownRow(&builder, tableHeaders)
+
+	separator := make([]string, len(tableHeaders))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeMarkdownRow(&builder, separator)
+
+	for _, row := range buildTableRows(rootPath, repos) {
+		cells := row.values()
+		cells[0] = "`" + strings.ReplaceAll(cells[0], "`", "'") + "`"
+		writeMarkdownRow(&builder, cells)
+	}
+
+	return builder.String()
+}
+
+// writeMarkdownRow writes a single Markdown table row, escaping cell content.
+func writeMarkdownRow(builder *strings.Builder, cells []string) {
+	builder.WriteString("|")
+	for _, cell := range cells {
+		builder.WriteString(" ")
+		builder.WriteString(escapeMarkdownCell(cell))
+		builder.WriteString(" |")
+	}
+	builder.WriteString("\n")
+}
+
+// escapeMarkdownCell makes a value safe to place inside a Markdown table cell.
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", " ")
+
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// stashCount returns the number of stashes, counting a stash of unknown count as one.
+func stashCount(status *models.GitStatus) int {
+	if status.HasStashes {
+		return max(status.StashCount, 1)
+	}
+
+	return 0
+}
@@ -0,0 +1,114 @@
+package tree
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tableTestRepos returns repositories covering every table column.
+func tableTestRepos() []*models.Repository {
+	return []*models.Repository{
+		{
+			Path: "/root/zeta",
+			Name: "zeta",
+			GitStatus: &models.GitStatus{
+				Branch:     "feature|x",
+				HasRemote:  true,
+				RemoteHost: "github.com",
+				Upstream:   "origin/feature|x",
+				Ahead:      2,
+				Behind:     1,
+				HasStashes: true,
+				StashCount: 2,
+				HasChanges: true,
+				Changes:    models.ChangeCounts{Staged: 3, Untracked: 5},
+				FetchError: "fetch failed",
+
+				LastCommitHash:    "0123456789abcdef0123456789abcdef01234567",
+				LastCommitSubject: "Fix parser",
+				LastCommitAuthor:  "Ada",
+				LastCommitDate:    time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Path:  "/root/nested/alpha",
+			Name:  "alpha",
+			Error: ErrCorruptedRepository,
+		},
+		{
+			Path:      "/root/beta",
+			Name:      "beta",
+			GitStatus: &models.GitStatus{Branch: "DETACHED", IsDetached: true, Operation: "REBASING 2/5"},
+		},
+	}
+}
+
+// Test FormatCSV renders a header and one sorted row per repository.
+func TestFormatCSV_RowsPerRepository(t *testing.T) {
+	output, err := FormatCSV("/root", tableTestRepos())
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	assert.Equal(t, tableHeaders, records[0])
+	assert.Equal(t, []string{
+		"beta", "DETACHED", "true", "REBASING 2/5", "false", "", "0", "0", "", "0", "false",
+		"0", "0", "0", "0", "0", "0", "", "", "", "", "", "",
+	}, records[1])
+	assert.Equal(t, []string{
+		"nested/alpha", "", "false", "", "false", "", "0", "0", "", "0", "false",
+		"0", "0", "0", "0", "0", "0", "corrupted repository", "", "", "", "", "",
+	}, records[2])
+	assert.Equal(t, []string{
+		"zeta", "feature|x", "false", "", "true", "github.com", "2", "1", "diverged", "2", "true",
+		"3", "0", "0", "0", "5", "0", "", "fetch failed", "0123456789abcdef0123456789abcdef01234567", "Fix parser", "Ada", "2024-06-01T09:00:00Z",
+	}, records[3])
+}
+
+// Test FormatMarkdown renders a table and escapes pipes in cell values.
+func TestFormatMarkdown_EscapesCells(t *testing.T) {
+	output := FormatMarkdown("/root", tableTestRepos())
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+
+	require.Len(t, lines, 5)
+	assert.Equal(t, "| path | branch | detached | operation | remote | remote_host | ahead | behind | sync_state | stashes | changes | "+
+		"staged | modified | deleted | renamed | untracked | conflicted | error | fetch_error | "+
+		"last_commit_hash | last_commit_subject | last_commit_author | last_commit_date |", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "| --- |"))
+	assert.Equal(t, "| `zeta` | feature\\|x | false |  | true | github.com | 2 | 1 | diverged | 2 | true | 3 | 0 | 0 | 0 | 5 | 0 | "+
+		" | fetch failed | 0123456789abcdef0123456789abcdef01234567 | Fix parser | Ada | 2024-06-01T09:00:00Z |", lines[4])
+}
+
+// Test a remote on a local path is reported as a remote without a host.
+func TestFormatCSV_LocalRemote(t *testing.T) {
+	repos := []*models.Repository{
+		{Path: "/root/local", Name: "local", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true}},
+		{Path: "/root/none", Name: "none", GitStatus: &models.GitStatus{Branch: "main"}},
+	}
+
+	output, err := FormatCSV("/root", repos)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"local", "true", ""}, []string{records[1][0], records[1][4], records[1][5]})
+	assert.Equal(t, []string{"none", "false", ""}, []string{records[2][0], records[2][4], records[2][5]})
+}
+
+// Test tabular renderers with no repositories produce only the header.
+func TestFormatTables_Empty(t *testing.T) {
+	output, err := FormatCSV("/root", nil)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(tableHeaders, ",")+"\n", output)
+
+	assert.Len(t, strings.Split(strings.TrimSpace(FormatMarkdown("/root", nil)), "\n"), 2)
+}
@@ -0,0 +1,400 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// Table-tree layout limits.
+const (
+	tableColumnGap      = 2  // Spaces between columns
+	tableMinNameWidth   = 12 // Narrowest the NAME column is truncated to
+	tableMinBranchWidth = 8  // Narrowest the BRANCH column is truncated to
+	tableEllipsis       = "…"
+)
+
+// Table-tree column indexes.
+const (
+	colName = iota
+	colBranch
+	colSync
+	colStash
+	colChanges
+	colAge
+	colRemote
+	colNotes
+	tableColumnCount
+)
+
+// tableTreeHeaders are the column titles of the table-tree layout, indexed by column.
+//
+//nolint:gochecknoglobals // Read-only column definition.
+var tableTreeHeaders = [tableColumnCount]string{
+	"NAME", "BRANCH", "SYNC", "STASH", "CHANGES", "AGE", "REMOTE", "NOTES",
+}
+
+// tableTreeDropOrder lists the columns removed, in order, when the layout is wider than FormatOptions.Width.
+//
+//nolint:gochecknoglobals // Read-only column definition.
+var tableTreeDropOrder = []int{colRemote, colAge}
+
+// tableCell is a single cell of the table-tree layout.
+type tableCell struct {
+	lead  string             // Uncolored leading text (tree connectors and node name)
+	keep  int                // Number of leading runes of lead that are never truncated
+	parts []models.Indicator // Colored indicators written after lead, separated by spaces
+}
+
+// width returns the number of terminal columns the cell occupies.
+func (c tableCell) width() int {
+	w := utf8.RuneCountInString(c.lead)
+	for i, part := range c.parts {
+		if i > 0 || c.lead != "" {
+			w++
+		}
+		w += utf8.RuneCountInString(part.Text)
+	}
+
+	return w
+}
+
+// render returns the cell text with colors applied.
+func (c tableCell) render() string {
+	var builder strings.Builder
+	builder.WriteString(c.lead)
+	for i, part := range c.parts {
+		if i > 0 || c.lead != "" {
+			builder.WriteString(" ")
+		}
+		builder.WriteString(part.Colored())
+	}
+
+	return builder.String()
+}
+
+// truncate shortens the cell to at most maxWidth columns, marking the cut with an ellipsis.
+// Trailing indicators are dropped first; then the node name or the remaining indicator is
+// shortened. Tree connectors are kept intact.
+func (c tableCell) truncate(maxWidth int) tableCell {
+	if c.width() <= maxWidth {
+		return c
+	}
+
+	// Drop trailing indicators (such as the upstream after the branch) before shortening text
+	for len(c.parts) > 1 && c.width() > maxWidth {
+		c.parts = c.parts[:len(c.parts)-1]
+	}
+
+	switch {
+	case c.width() <= maxWidth:
+	case len(c.parts) == 0:
+		runes := []rune(c.lead)
+		cut := max(maxWidth-1, c.keep)
+		if cut < len(runes) {
+			c.lead = string(runes[:cut]) + tableEllipsis
+		}
+	case len(c.parts) == 1 && c.lead == "":
+		runes := []rune(c.parts[0].Text)
+		if cut := max(maxWidth-1, 1); cut < len(runes) {
+			part := c.parts[0]
+			part.Text = string(runes[:cut]) + tableEllipsis
+			c.parts = []models.Indicator{part}
+		}
+	}
+
+	return c
+}
+
+// tableTreeRow is one line of the table-tree layout.
+type tableTreeRow [tableColumnCount]tableCell
+
+// FormatTable generates a table-tree layout: tree connectors and names in the left column,
+// followed by aligned BRANCH, SYNC, STASH, CHANGES, AGE, REMOTE and NOTES columns.
+// Columns that are empty for every repository are omitted. When opts.Width is positive and
+// the layout is wider, the REMOTE and AGE columns are dropped first, then branch names and
+// node names are truncated. opts.LineTemplate is ignored.
+func FormatTable(root *models.TreeNode, opts *FormatOptions) string {
+	if opts == nil {
+		opts = DefaultFormatOptions()
+	}
+
+	if root == nil {
+		return ""
+	}
+
+	now := opts.now()
+
+	header := tableTreeRow{}
+	for i, title := range tableTreeHeaders {
+		header[i] = tableCell{lead: title}
+	}
+	if opts.ShowRoot {
+		header[colName] = tableCell{lead: opts.RootLabel}
+	}
+
+	var rows []tableTreeRow
+	for i, child := range root.Children {
+		rows = collectTableRows(rows, child, "", i == len(root.Children)-1, now)
+	}
+
+	visible := tableVisibleColumns(rows)
+	widths := tableColumnWidths(header, rows)
+	fitTableWidth(&visible, &widths, opts.Width)
+
+	var builder strings.Builder
+	writeTableRow(&builder, header, visible, widths)
+	for _, row := range rows {
+		writeTableRow(&builder, row, visible, widths)
+	}
+
+	return builder.String()
+}
+
+// collectTableRows appends the rows for node and its descendants in display order.
+func collectTableRows(rows []tableTreeRow, node *models.TreeNode, prefix string, isLast bool, now time.Time) []tableTreeRow {
+	if node == nil || node.Repository == nil {
+		return rows
+	}
+
+	symbols := models.CurrentTheme().Symbols
+	connector := symbols.TreeBranch
+	childPrefix := prefix + symbols.TreeVertical
+	if isLast {
+		connector = symbols.TreeLast
+		childPrefix = prefix + symbols.TreeSpace
+	}
+
+	lead := prefix + connector
+	row := tableTreeRow{}
+	row[colName] = tableCell{lead: lead + node.Name(), keep: utf8.RuneCountInString(lead)}
+	if !node.IsDirectory {
+		fillStatusCells(&row, node.Repository, now)
+	}
+	rows = append(rows, row)
+
+	// Local branches come first, as leaves above the nested repositories
+	branches := nodeBranches(node)
+	total := len(branches) + len(node.Children)
+	for i, branch := range branches {
+		connector := symbols.TreeBranch
+		if i == total-1 {
+			connector = symbols.TreeLast
+		}
+		rows = append(rows, branchTableRow(branch, childPrefix+connector, now))
+	}
+
+	for i, child := range node.Children {
+		rows = collectTableRows(rows, child, childPrefix, len(branches)+i == total-1, now)
+	}
+
+	return rows
+}
+
+// branchTableRow returns the row of a local branch: its upstream in the BRANCH column, the
+// unpushed count or no-remote marker in SYNC and the age of its last commit in AGE.
+func branchTableRow(branch models.BranchStatus, lead string, now time.Time) tableTreeRow {
+	row := tableTreeRow{}
+	row[colName] = tableCell{lead: lead + branch.Name, keep: utf8.RuneCountInString(lead)}
+	for _, ind := range branch.Indicators() {
+		if ind.Kind == models.KindUpstream {
+			row[colBranch].parts = append(row[colBranch].parts, ind)
+		} else {
+			row[colSync].parts = append(row[colSync].parts, ind)
+		}
+	}
+	if !branch.LastCommitDate.IsZero() {
+		row[colAge].parts = []models.Indicator{{Text: formatAge(now.Sub(branch.LastCommitDate)), Color: models.ColorGray}}
+	}
+
+	return row
+}
+
+// fillStatusCells places a repository's status indicators into their columns.
+func fillStatusCells(row *tableTreeRow, repo *models.Repository, now time.Time) {
+	status := repo.GitStatus
+	notes := &row[colNotes]
+
+	if status != nil {
+		for _, ind := range status.Indicators() {
+			switch ind.Kind {
+			case models.KindBranch, models.KindUpstream:
+				row[colBranch].parts = append(row[colBranch].parts, ind)
+			case models.KindUpstreamGone, models.KindDiverged, models.KindFastForward, models.KindAhead, models.KindBehind,
+				models.KindBaseAhead, models.KindBaseBehind, models.KindNoRemote:
+				row[colSync].parts = append(row[colSync].parts, ind)
+			case models.KindStashes:
+				row[colStash].parts = append(row[colStash].parts, ind)
+			case models.KindChanges, models.KindStaged, models.KindModified, models.KindDeleted,
+				models.KindRenamed, models.KindUntracked, models.KindConflicted:
+				row[colChanges].parts = append(row[colChanges].parts, ind)
+			case models.KindOperation, models.KindSubmodules, models.KindError, models.KindFetchError:
+				notes.parts = append(notes.parts, ind)
+			}
+		}
+
+		if !status.LastCommitDate.IsZero() {
+			row[colAge].parts = []models.Indicator{{Text: formatAge(now.Sub(status.LastCommitDate)), Color: models.ColorGray}}
+		}
+		if status.RemoteHost != "" {
+			row[colRemote].parts = []models.Indicator{{Text: status.RemoteHost, Color: models.ColorGray}}
+		}
+	}
+
+	// Same conditions as the flags written by writeNodeText
+	if repo.Error != nil && status == nil {
+		notes.parts = append(notes.parts, models.Indicator{Kind: models.KindError, Text: "error", Color: models.ColorRed})
+	}
+	if repo.HasTimeout && status != nil && status.Error != "" {
+		notes.parts = append(notes.parts, models.Indicator{Text: "timeout", Color: models.ColorRed})
+	}
+	if repo.IsWorktree {
+		notes.parts = append(notes.parts, models.Indicator{Text: "worktree", Color: models.ColorGray})
+	}
+	if repo.SubmoduleOf != "" {
+		notes.parts = append(notes.parts, models.Indicator{Text: "submodule", Color: models.ColorGray})
+		if repo.SubmoduleState != models.SubmoduleInSync {
+			notes.parts = append(notes.parts, models.Indicator{Text: string(repo.SubmoduleState), Color: models.ColorYellow})
+		}
+	}
+	if repo.IsBare && status != nil {
+		notes.parts = append(notes.parts, models.Indicator{Text: "bare", Color: models.ColorGray})
+	}
+}
+
+// tableVisibleColumns reports which columns have content in at least one row.
+// The NAME column is always visible.
+func tableVisibleColumns(rows []tableTreeRow) [tableColumnCount]bool {
+	var visible [tableColumnCount]bool
+	visible[colName] = true
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if cell.width() > 0 {
+				visible[i] = true
+			}
+		}
+	}
+
+	return visible
+}
+
+// tableColumnWidths returns the widest cell of each column.
+func tableColumnWidths(header tableTreeRow, rows []tableTreeRow) [tableColumnCount]int {
+	var widths [tableColumnCount]int
+	for i, cell := range header {
+		widths[i] = cell.width()
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], cell.width())
+		}
+	}
+
+	return widths
+}
+
+// tableTotalWidth returns the width of a line with all visible columns filled.
+func tableTotalWidth(visible [tableColumnCount]bool, widths [tableColumnCount]int) int {
+	total := 0
+	count := 0
+	for i, w := range widths {
+		if visible[i] {
+			total += w
+			count++
+		}
+	}
+
+	return total + (count-1)*tableColumnGap
+}
+
+// fitTableWidth drops and narrows columns until the layout fits into maxWidth.
+// A non-positive maxWidth means the width is unlimited.
+func fitTableWidth(visible *[tableColumnCount]bool, widths *[tableColumnCount]int, maxWidth int) {
+	if maxWidth <= 0 {
+		return
+	}
+
+	for _, col := range tableTreeDropOrder {
+		if tableTotalWidth(*visible, *widths) <= maxWidth {
+			return
+		}
+		visible[col] = false
+	}
+
+	for _, shrink := range []struct{ col, minWidth int }{
+		{colBranch, tableMinBranchWidth},
+		{colName, tableMinNameWidth},
+	} {
+		excess := tableTotalWidth(*visible, *widths) - maxWidth
+		if excess <= 0 || !visible[shrink.col] {
+			continue
+		}
+		widths[shrink.col] = max(widths[shrink.col]-excess, min(shrink.minWidth, widths[shrink.col]))
+	}
+}
+
+// writeTableRow writes one line, truncating and padding each visible cell to its column width.
+func writeTableRow(builder *strings.Builder, row tableTreeRow, visible [tableColumnCount]bool, widths [tableColumnCount]int) {
+	var line strings.Builder
+	pending := 0
+
+	for i, cell := range row {
+		if !visible[i] {
+			continue
+		}
+
+		if i > colName {
+			pending += tableColumnGap
+		}
+
+		cell = cell.truncate(widths[i])
+		if cell.width() > 0 {
+			line.WriteString(strings.Repeat(" ", pending))
+			line.WriteString(cell.render())
+			pending = 0
+		}
+		pending += widths[i] - cell.width()
+	}
+
+	builder.WriteString(line.String())
+	builder.WriteString("\n")
+}
+
+// formatRelativeAge renders a duration as a relative age such as "3d ago" or "just now".
+func formatRelativeAge(d time.Duration) string {
+	age := formatAge(d)
+	if age == "now" {
+		return "just now"
+	}
+
+	return age + " ago"
+}
+
+// formatAge renders a duration as a short age such as "45m", "3h", "12d", "5mo" or "2y".
+func formatAge(d time.Duration) string {
+	const (
+		day   = 24 * time.Hour
+		month = 30 * day
+		year  = 365 * day
+	)
+
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d < day:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d < 2*month:
+		return fmt.Sprintf("%dd", d/day)
+	case d < year:
+		return fmt.Sprintf("%dmo", d/month)
+	default:
+		return fmt.Sprintf("%dy", d/year)
+	}
+}
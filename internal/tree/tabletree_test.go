@@ -0,0 +1,168 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tableTestNow is the reference time used for commit ages in table tests.
+//
+//nolint:gochecknoglobals // Test fixture.
+var tableTestNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+// newTableTestTree builds a small tree with varied statuses for table tests.
+func newTableTestTree() *models.TreeNode {
+	repos := []*models.Repository{
+		{
+			Path: "/root/api",
+			Name: "api",
+			GitStatus: &models.GitStatus{
+				Branch:         "feature/long-branch-name",
+				HasRemote:      true,
+				RemoteHost:     "github.com",
+				Ahead:          2,
+				Behind:         1,
+				HasChanges:     true,
+				LastCommitDate: tableTestNow.Add(-3 * 24 * time.Hour),
+			},
+		},
+		{
+			Path: "/root/libs/core",
+			Name: "core",
+			GitStatus: &models.GitStatus{
+				Branch:         "main",
+				HasStashes:     true,
+				LastCommitDate: tableTestNow.Add(-5 * time.Hour),
+			},
+		},
+	}
+
+	return Build("/root", repos, DefaultFormatOptions())
+}
+
+// Test FormatTable aligns status columns to the right of the tree.
+func TestFormatTable_AlignsColumns(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	opts := DefaultFormatOptions()
+	opts.Now = tableTestNow
+
+	output := FormatTable(newTableTestTree(), opts)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	require.Len(t, lines, 4)
+	assert.Equal(t, ".             BRANCH                    SYNC     STASH  CHANGES  AGE  REMOTE", lines[0])
+	assert.Equal(t, "├── api       feature/long-branch-name  ⇕ ↑2 ↓1         *        3d   github.com", lines[1])
+	assert.Equal(t, "└── libs", lines[2])
+	assert.Equal(t, "    └── core  main                      ○        $               5h", lines[3])
+}
+
+// Test FormatTable drops low-priority columns and truncates to fit the width.
+func TestFormatTable_FitsWidth(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	opts := DefaultFormatOptions()
+	opts.Now = tableTestNow
+
+	full := FormatTable(newTableTestTree(), opts)
+	assert.Contains(t, full, "REMOTE")
+
+	opts.Width = 52
+	output := FormatTable(newTableTestTree(), opts)
+
+	assert.NotContains(t, output, "REMOTE")
+	assert.NotContains(t, output, "github.com")
+	assert.NotContains(t, output, "AGE")
+	assert.Contains(t, output, "feature/long…")
+	assert.Contains(t, output, "⇕ ↑2 ↓1")
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		assert.LessOrEqual(t, len([]rune(line)), opts.Width, "line too wide: %q", line)
+	}
+}
+
+// Test FormatTable shows error and bare flags in the NOTES column.
+func TestFormatTable_Notes(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{Path: "/root/broken", Name: "broken", Error: ErrCorruptedRepository},
+		{Path: "/root/mirror", Name: "mirror", IsBare: true, GitStatus: &models.GitStatus{Branch: "main", HasRemote: true}},
+	}
+
+	output := FormatTable(Build("/root", repos, DefaultFormatOptions()), DefaultFormatOptions())
+
+	assert.Contains(t, output, "NOTES")
+	assert.Regexp(t, `broken\s+error`, output)
+	assert.Regexp(t, `mirror\s+main\s+bare`, output)
+}
+
+// Test FormatTable with an empty tree prints only the root label.
+func TestFormatTable_Empty(t *testing.T) {
+	output := FormatTable(Build("/root", nil, DefaultFormatOptions()), DefaultFormatOptions())
+	assert.Equal(t, ".\n", output)
+	assert.Empty(t, FormatTable(nil, nil))
+}
+
+// Test formatAge renders short relative ages.
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-time.Hour, "now"},
+		{30 * time.Second, "now"},
+		{45 * time.Minute, "45m"},
+		{5 * time.Hour, "5h"},
+		{12 * 24 * time.Hour, "12d"},
+		{150 * 24 * time.Hour, "5mo"},
+		{800 * 24 * time.Hour, "2y"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatAge(tt.d), "duration %v", tt.d)
+	}
+}
+
+// Test FormatTable places local branches in rows below their repository.
+func TestFormatTable_LocalBranches(t *testing.T) {
+	origNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = origNoColor }()
+
+	repos := []*models.Repository{
+		{
+			Path: "/root/app",
+			Name: "app",
+			GitStatus: &models.GitStatus{
+				Branch: "main", HasRemote: true,
+				Branches: []models.BranchStatus{
+					{Name: "main", Upstream: "origin/main", LastCommitDate: tableTestNow.Add(-2 * time.Hour)},
+					{Name: "spike", Unpushed: 3, LastCommitDate: tableTestNow.Add(-3 * 24 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	opts := DefaultFormatOptions()
+	opts.Now = tableTestNow
+	output := FormatTable(Build("/root", repos, opts), opts)
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	require.Len(t, lines, 4)
+	assert.Equal(t, ".              BRANCH        SYNC  AGE", lines[0])
+	assert.Equal(t, "└── app        main", lines[1])
+	assert.Equal(t, "    ├── main   →origin/main        2h", lines[2])
+	assert.Equal(t, "    └── spike                ○ ↑3  3d", lines[3])
+}
@@ -0,0 +1,121 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+)
+
+// LineData is the data passed to a user-defined line template for each tree node.
+// Tree connectors are written by the formatter; the template only renders the text after them.
+// The flat status fields are zero for directories and unknown statuses, so templates using
+// them work for every node without guarding against a nil GitStatus.
+type LineData struct {
+	Repository   *models.Repository // The repository (a placeholder with Path and Name for directories)
+	GitStatus    *models.GitStatus  // Git status of the repository (nil for directories or on error)
+	Name         string             // Display name of the node
+	Depth        int                // Depth level in the tree (1 = top-level entries)
+	RelativePath string             // Path relative to scan root
+	IsDirectory  bool               // Whether the node is a plain directory rather than a repository
+
+	Branch         string    // Checked-out branch, or "" without a status
+	Ahead          int       // Commits ahead of the upstream
+	Behind         int       // Commits behind the upstream
+	HasChanges     bool      // Whether there are uncommitted changes
+	LastCommitDate time.Time // Date of the HEAD commit, or the zero time
+}
+
+// newLineData builds template data for a tree node.
+func newLineData(node *models.TreeNode) LineData {
+	return newLineDataFor(node.Repository, node.Name(), node.Depth, node.RelativePath, node.IsDirectory)
+}
+
+// newLineDataFor builds template data for a repository or directory placeholder.
+func newLineDataFor(repo *models.Repository, name string, depth int, relPath string, isDirectory bool) LineData {
+	data := LineData{
+		Repository:   repo,
+		GitStatus:    repo.GitStatus,
+		Name:         name,
+		Depth:        depth,
+		RelativePath: relPath,
+		IsDirectory:  isDirectory,
+	}
+	if status := repo.GitStatus; status != nil {
+		data.Branch = status.Branch
+		data.Ahead = status.Ahead
+		data.Behind = status.Behind
+		data.HasChanges = status.HasChanges
+		data.LastCommitDate = status.LastCommitDate
+	}
+
+	return data
+}
+
+// TemplateFuncs returns the helper functions available to line templates:
+//   - gray, yellow, green, red: wrap text in the corresponding bold color
+//   - colored: render a models.Indicator in its own color
+//   - status: the default "[[ branch | ... ]]" status string for a GitStatus
+//   - ago: the time since a date, such as "3d ago", or "" for a zero time
+//   - join: strings.Join
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"gray":   func(s string) string { return models.ColorText(models.ColorGray, s) },
+		"yellow": func(s string) string { return models.ColorText(models.ColorYellow, s) },
+		"green":  func(s string) string { return models.ColorText(models.ColorGreen, s) },
+		"red":    func(s string) string { return models.ColorText(models.ColorRed, s) },
+		"colored": func(ind models.Indicator) string {
+			return ind.Colored()
+		},
+		"status": func(status *models.GitStatus) string {
+			if status == nil {
+				return ""
+			}
+
+			return status.Format()
+		},
+		"ago": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+
+			return formatRelativeAge(time.Since(t))
+		},
+		"join": strings.Join,
+	}
+}
+
+// ParseLineTemplate parses a user-defined line template and checks that it executes
+// against representative repository and directory nodes.
+func ParseLineTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("line").Funcs(TemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	repo := &models.Repository{Path: "/example/repo", Name: "repo", GitStatus: &models.GitStatus{Branch: "main", HasRemote: true}}
+	if err := tmpl.Execute(&strings.Builder{}, newLineDataFor(repo, "repo", 1, "repo", false)); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	dir := &models.Repository{Path: "/example/dir", Name: "dir"}
+	if err := tmpl.Execute(&strings.Builder{}, newLineDataFor(dir, "dir", 1, "dir", true)); err != nil {
+		return nil, fmt.Errorf("invalid template: directories have no .GitStatus, "+
+			"use .Branch, .Ahead, .Behind or {{with .GitStatus}}: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderLineTemplate executes the line template for a node. Trailing newlines are removed so
+// templates loaded from files do not produce blank lines; execution errors are rendered inline.
+func renderLineTemplate(tmpl *template.Template, node *models.TreeNode) string {
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, newLineData(node)); err != nil {
+		return fmt.Sprintf("%s <template error: %v>", node.Name(), err)
+	}
+
+	return strings.TrimRight(builder.String(), "\r\n")
+}
@@ -0,0 +1,140 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/gitree/internal/models"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test Format uses the line template for node text while keeping tree connectors.
+func TestFormat_WithLineTemplate(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	repos := []*models.Repository{
+		{
+			Path:      "/root/project1",
+			Name:      "project1",
+			GitStatus: &models.GitStatus{Branch: "main", HasRemote: true, Ahead: 2},
+		},
+		{
+			Path:      "/root/nested/project2",
+			Name:      "project2",
+			GitStatus: &models.GitStatus{Branch: "develop"},
+		},
+	}
+
+	tmpl, err := ParseLineTemplate(
+		"{{if .IsDirectory}}{{.Name}}/{{else}}{{.RelativePath}} @{{.Depth}}" +
+			"{{with .GitStatus}} {{green .Branch}} +{{.Ahead}}{{end}}{{end}}\n")
+	require.NoError(t, err)
+
+	opts := DefaultFormatOptions()
+	opts.LineTemplate = tmpl
+
+	root := Build("/root", repos, opts)
+	output := Format(root, opts)
+
+	expected := strings.Join([]string{
+		".",
+		"├── nested/",
+		"│   └── nested/project2 @2 develop +0",
+		"└── project1 @1 main +2",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, output)
+}
+
+// Test template helpers expose the default status and colored indicators.
+func TestFormat_LineTemplateHelpers(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	repos := []*models.Repository{
+		{
+			Path:      "/root/project",
+			Name:      "project",
+			GitStatus: &models.GitStatus{Branch: "feature", HasRemote: true, Behind: 3},
+		},
+	}
+
+	tmpl, err := ParseLineTemplate(
+		`{{.Name}} {{status .GitStatus}}{{with .GitStatus}}{{range .Indicators}} <{{colored .}}>{{end}}{{end}}`)
+	require.NoError(t, err)
+
+	opts := DefaultFormatOptions()
+	opts.LineTemplate = tmpl
+
+	output := Format(Build("/root", repos, opts), opts)
+	assert.Contains(t, output, "└── project [[ feature | ↓3 ]] <feature> <↓3>")
+}
+
+// Test the ago helper renders the age of the last commit.
+func TestFormat_LineTemplateAgo(t *testing.T) {
+	repos := []*models.Repository{
+		{
+			Path:      "/root/project",
+			Name:      "project",
+			GitStatus: &models.GitStatus{Branch: "main", LastCommitDate: time.Now().Add(-50 * time.Hour)},
+		},
+		{Path: "/root/empty", Name: "empty", GitStatus: &models.GitStatus{Branch: "main"}},
+	}
+
+	tmpl, err := ParseLineTemplate(`{{.Name}}{{with .GitStatus}} ({{ago .LastCommitDate}}){{end}}`)
+	require.NoError(t, err)
+
+	opts := DefaultFormatOptions()
+	opts.LineTemplate = tmpl
+
+	output := Format(Build("/root", repos, opts), opts)
+	assert.Contains(t, output, "project (2d ago)")
+	assert.Contains(t, output, "empty ()")
+}
+
+// Test ParseLineTemplate rejects templates that fail to parse or execute.
+func TestParseLineTemplate_Invalid(t *testing.T) {
+	_, err := ParseLineTemplate("{{.Name")
+	require.Error(t, err)
+
+	_, err = ParseLineTemplate("{{.NoSuchField}}")
+	require.Error(t, err)
+
+	// Directories have no GitStatus, so unguarded access must be rejected up front
+	_, err = ParseLineTemplate("{{.GitStatus.Branch}}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use .Branch")
+}
+
+// Test the flat status fields work for repositories, directories and unknown statuses alike.
+func TestFormat_LineTemplateFlatFields(t *testing.T) {
+	color.NoColor = true
+	defer func() { color.NoColor = false }()
+
+	repos := []*models.Repository{
+		{Path: "/root/nested/project1", Name: "project1", GitStatus: &models.GitStatus{Branch: "main", Ahead: 2, Behind: 1}},
+		{Path: "/root/broken", Name: "broken"},
+	}
+
+	tmpl, err := ParseLineTemplate("{{.Name}} {{.Branch}}{{if .Ahead}} ↑{{.Ahead}}{{end}}{{if .Behind}} ↓{{.Behind}}{{end}}")
+	require.NoError(t, err)
+
+	opts := DefaultFormatOptions()
+	opts.LineTemplate = tmpl
+
+	root := Build("/root", repos, opts)
+	output := Format(root, opts)
+
+	expected := strings.Join([]string{
+		".",
+		"├── broken ",
+		"└── nested ",
+		"    └── project1 main ↑2 ↓1",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, output)
+}